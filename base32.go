@@ -0,0 +1,47 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"encoding/base32"
+	"fmt"
+)
+
+// EncodeBase32 writes src encoded with enc at the current position and
+// advances the position. enc selects the alphabet and padding, e.g.
+// base32.StdEncoding, base32.HexEncoding or
+// base32.StdEncoding.WithPadding(base32.NoPadding).
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) EncodeBase32(enc *base32.Encoding, src []byte) {
+	n := enc.EncodedLen(len(src))
+	required := b.pos + n
+	if !b.checkWritable("EncodeBase32", required) {
+		return
+	}
+
+	enc.Encode(b.data[b.pos:required], src)
+	b.pos += n
+}
+
+// DecodeBase32 decodes the next n bytes, encoded with enc, into a new slice
+// and advances the position past them. With padding, n must cover whole
+// 8-character groups; without padding, a trailing partial group is allowed.
+// Returns an error, without advancing, if the input is malformed.
+func (b *Buffer) DecodeBase32(enc *base32.Encoding, n int) ([]byte, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("mbuff.Buffer.DecodeBase32: negative length %d", n)
+	}
+	if !b.checkReadable(n) {
+		return nil, b.err
+	}
+	dst := make([]byte, enc.DecodedLen(n))
+	m, err := enc.Decode(dst, b.data[b.pos:b.pos+n])
+	if err != nil {
+		return nil, fmt.Errorf("mbuff.Buffer.DecodeBase32: %w", err)
+	}
+	b.pos += n
+	return dst[:m], nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"encoding/base32"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBase32 tests base32 encoding into and decoding from the buffer.
+func TestBase32(t *testing.T) {
+	raw := base32.StdEncoding.WithPadding(base32.NoPadding)
+
+	b := NewBuilder(0)
+	b.EncodeBase32(base32.StdEncoding, []byte("foobar"))
+	b.EncodeBase32(base32.HexEncoding, []byte("f"))
+	b.EncodeBase32(raw, []byte("fo"))
+	assert.Equal(t, "MZXW6YTBOI======"+"CO======"+"MZXQ", b.String())
+
+	b.Rewind()
+	v, err := b.DecodeBase32(base32.StdEncoding, 16)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("foobar"), v)
+	v, err = b.DecodeBase32(base32.HexEncoding, 8)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("f"), v)
+	v, err = b.DecodeBase32(raw, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("fo"), v)
+	assert.Equal(t, 0, b.Readable())
+
+	// Malformed input does not advance
+	r := NewBufferFrom([]byte("MZXW6Y!BOI======"))
+	_, err = r.DecodeBase32(base32.StdEncoding, 16)
+	assert.Error(t, err)
+	r = NewBufferFrom([]byte("MZXQ"))
+	_, err = r.DecodeBase32(base32.StdEncoding, 4) // missing padding
+	assert.Error(t, err)
+	assert.Equal(t, 0, r.Pos())
+	_, err = r.DecodeBase32(base32.StdEncoding, -1)
+	assert.Error(t, err)
+	assert.Panics(t, func() { _, _ = r.DecodeBase32(raw, 5) })
+
+	fixed := NewBuffer(4)
+	assert.Panics(t, func() { fixed.EncodeBase32(base32.StdEncoding, []byte("f")) })
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"fmt"
+)
+
+// checkBitWidth panics if nbits is not a valid element width for a
+// bit-packed array.
+func checkBitWidth(method string, nbits int) {
+	if nbits < 1 || nbits > 32 {
+		panic(fmt.Sprintf("mbuff.%s: invalid bit width %d", method, nbits))
+	}
+}
+
+// checkBitsValues returns an error if a value of v does not fit in nbits bits.
+func checkBitsValues(method string, nbits int, v []uint32) error {
+	for i, val := range v {
+		if nbits < 32 && val>>nbits != 0 {
+			return fmt.Errorf("mbuff.%s: value 0x%X at index %d exceeds %d bits", method, val, i, nbits)
+		}
+	}
+	return nil
+}
+
+// bitsLen returns the number of bytes holding count values of nbits each.
+func bitsLen(nbits, count int) int { return (count*nbits + 7) >> 3 }
+
+// PutBitsArr writes v as a bit-packed array of nbits-wide values at the
+// current position and advances the position. Values are packed contiguously
+// MSB-first, so a value may straddle byte boundaries; the unused low bits of
+// the final byte are zero. For example, 12-bit values 0xABC and 0xDEF are
+// written as AB CD EF. The position always advances by whole bytes.
+// Panics if nbits is not in 1..32. A value that does not fit in nbits bits is
+// an error and nothing is written.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutBitsArr(nbits int, v []uint32) {
+	checkBitWidth("Buffer.PutBitsArr", nbits)
+	if err := checkBitsValues("Buffer.PutBitsArr", nbits, v); err != nil {
+		b.fail(err)
+		return
+	}
+	b.putBitsArr(nbits, v)
+}
+
+// putBitsArr writes v, already validated, as a bit-packed array.
+func (b *Buffer) putBitsArr(nbits int, v []uint32) {
+	byteLen := bitsLen(nbits, len(v))
+	required := b.pos + byteLen
+	if !b.checkWritable("PutBitsArr", required) {
+		return
+	}
+
+	b.traceOp("PutBitsArr", byteLen, 0)
+	var acc uint64
+	accBits := 0
+	writePos := b.pos
+	for _, val := range v {
+		acc = acc<<nbits | uint64(val)
+		accBits += nbits
+		for accBits >= 8 {
+			accBits -= 8
+			b.data[writePos] = byte(acc >> accBits)
+			writePos++
+		}
+	}
+	if accBits > 0 {
+		b.data[writePos] = byte(acc << (8 - accBits))
+	}
+	b.pos += byteLen
+}
+
+// TakeBitsArr reads len(out) nbits-wide values packed as by PutBitsArr into
+// out, then advances the position past the whole bytes holding them.
+// Panics if nbits is not in 1..32.
+func (b *Buffer) TakeBitsArr(nbits int, out []uint32) {
+	checkBitWidth("Buffer.TakeBitsArr", nbits)
+	byteLen := bitsLen(nbits, len(out))
+	if !b.checkReadable(byteLen) {
+		return
+	}
+
+	b.traceOp("TakeBitsArr", byteLen, 0)
+	var acc uint64
+	accBits := 0
+	readPos := b.pos
+	mask := uint64(1)<<nbits - 1
+	for i := range out {
+		for accBits < nbits {
+			acc = acc<<8 | uint64(b.data[readPos])
+			readPos++
+			accBits += 8
+		}
+		accBits -= nbits
+		out[i] = uint32(acc >> accBits & mask)
+	}
+	b.pos += byteLen
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBitsArr tests bit-packed array layouts for common widths.
+func TestBitsArr(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutBitsArr(12, []uint32{0xABC, 0xDEF, 0x123})
+	assert.Equal(t, []byte{0xAB, 0xCD, 0xEF, 0x12, 0x30}, b.Bytes())
+
+	b.Rewind()
+	out := make([]uint32, 3)
+	b.TakeBitsArr(12, out)
+	assert.Equal(t, []uint32{0xABC, 0xDEF, 0x123}, out)
+	assert.Equal(t, 5, b.Pos())
+
+	b.Clear()
+	b.PutBitsArr(10, []uint32{0x3FF, 0x000, 0x2AA, 0x155})
+	assert.Equal(t, []byte{0xFF, 0xC0, 0x0A, 0xA9, 0x55}, b.Bytes())
+
+	b.Clear()
+	b.PutBitsArr(1, []uint32{1, 0, 1})
+	assert.Equal(t, []byte{0xA0}, b.Bytes())
+}
+
+// TestBitsArr_RoundTrip tests round trips for all widths.
+func TestBitsArr_RoundTrip(t *testing.T) {
+	for nbits := 1; nbits <= 32; nbits++ {
+		in := make([]uint32, 7)
+		for i := range in {
+			in[i] = uint32(uint64(0x9E3779B9)*uint64(i+1)) >> (32 - nbits)
+		}
+		b := NewBuilder(0)
+		b.PutU8(0xEE)
+		b.PutBitsArr(nbits, in)
+		b.PutU8(0xEE)
+		assert.Equal(t, 2+(7*nbits+7)/8, b.Count())
+
+		b.Rewind()
+		b.Skip(1)
+		out := make([]uint32, len(in))
+		b.TakeBitsArr(nbits, out)
+		assert.Equal(t, in, out, "nbits %d", nbits)
+		assert.Equal(t, uint8(0xEE), b.TakeU8())
+	}
+}
+
+// TestBitsArr_Invalid tests invalid widths, oversized values and short input.
+func TestBitsArr_Invalid(t *testing.T) {
+	b := NewBuilder(0)
+	assert.Panics(t, func() { b.PutBitsArr(0, nil) })
+	assert.Panics(t, func() { b.PutBitsArr(33, nil) })
+	assert.Panics(t, func() { b.TakeBitsArr(0, nil) })
+
+	b.SetStrictMode(true)
+	b.PutBitsArr(12, []uint32{0x1000})
+	assert.ErrorContains(t, b.Err(), "mbuff.Builder.PutBitsArr: value 0x1000 at index 0 exceeds 12 bits")
+	assert.Equal(t, 0, b.Count())
+	assert.Equal(t, 0, b.Capacity()) // rejected before growing
+
+	r := NewBufferFrom([]byte{0xAB, 0xCD})
+	assert.Panics(t, func() { r.TakeBitsArr(12, make([]uint32, 2)) })
+}
@@ -0,0 +1,31 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+// boolByte returns 1 for true and 0 for false.
+func boolByte(v bool) uint8 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// PutBool writes v as one byte, 0x01 for true and 0x00 for false, at the
+// current position and advances the position.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutBool(v bool) { b.PutU8(boolByte(v)) }
+
+// TakeBool reads one byte at the current position, then advances the
+// position. Any non-zero byte is true.
+func (b *Buffer) TakeBool() bool { return b.TakeU8() != 0 }
+
+// PeekBool reads one byte at pos+offset without advancing the position.
+// Any non-zero byte is true.
+func (b *Buffer) PeekBool(offset int) bool { return b.PeekU8(offset) != 0 }
+
+// OverwriteBool overwrites one byte at the specified offset with 0x01 for
+// true and 0x00 for false.
+func (b *Buffer) OverwriteBool(offset int, v bool) { b.OverwriteU8(offset, boolByte(v)) }
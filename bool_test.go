@@ -0,0 +1,36 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBool tests one-byte boolean fields.
+func TestBool(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutBool(true)
+	b.PutBool(false)
+	b.PutU8(0x80)
+	assert.Equal(t, []byte{0x01, 0x00, 0x80}, b.Bytes())
+
+	b.Rewind()
+	assert.True(t, b.PeekBool(0))
+	assert.True(t, b.PeekBool(2), "any non-zero byte is true")
+	assert.True(t, b.TakeBool())
+	assert.False(t, b.TakeBool())
+	assert.True(t, b.TakeBool())
+
+	b.OverwriteBool(0, false)
+	b.OverwriteBool(2, true)
+	assert.Equal(t, []byte{0x00, 0x00, 0x01}, b.Bytes())
+
+	assert.Panics(t, func() { b.TakeBool() })
+	assert.Panics(t, func() { b.OverwriteBool(3, true) })
+	assert.Panics(t, func() { NewBuffer(0).PutBool(true) })
+}
@@ -6,26 +6,44 @@
 package mbuff
 
 import (
+	"bytes"
+	"encoding/base32"
 	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"unicode/utf16"
+	"unicode/utf8"
 )
 
+// minRead is the minimum space reserved before each read from an io.Reader.
+const minRead = 512
+
+// maxEmptyReads is the number of consecutive empty reads after which a reader
+// is assumed to be broken, as in bufio.
+const maxEmptyReads = 100
+
 type Builder struct {
 	Buffer
+	maxCap int           // maximum capacity, or 0 for unlimited
+	nested []nestedFrame // open sub-messages, innermost last
 }
 
 func NewBuilder(capacity int) *Builder {
 	return &Builder{
-		Buffer{
+		Buffer: Buffer{
 			data:   make([]byte, 0, capacity),
 			pos:    0,
 			order:  binary.BigEndian,
 			hlswap: false,
-		}}
+		},
+	}
 }
 
 func NewBuilderFrom(buffer []byte) *Builder {
 	return &Builder{
-		Buffer{
+		Buffer: Buffer{
 			data:   buffer,
 			pos:    0,
 			order:  binary.BigEndian,
@@ -34,11 +52,67 @@ func NewBuilderFrom(buffer []byte) *Builder {
 	}
 }
 
+// Detach returns a new Builder seeded with a copy of b's readable region
+// [pos:len], positioned at 0 and with b's byte order, swap and failure mode
+// settings. Unlike ReadableSince, which is a fixed view sharing b's storage,
+// the Builder is independent of b and grows as it is written.
+func (b *Buffer) Detach() *Builder {
+	return &Builder{
+		Buffer: Buffer{
+			data:    bytes.Clone(b.data[b.pos:len(b.data):len(b.data)]),
+			order:   b.order,
+			hlswap:  b.hlswap,
+			errMode: b.errMode,
+		},
+	}
+}
+
+// AsBuilder returns a Builder over b's data, starting at b's position and
+// with its byte order, swap and failure mode settings, so a function given a
+// *Buffer can opt into growth. The two share storage until the Builder grows
+// beyond b's capacity, after which it writes to a new backing array. Their
+// positions and counts are independent from the start.
+func (b *Buffer) AsBuilder() *Builder {
+	return &Builder{
+		Buffer: Buffer{
+			data:    b.data,
+			pos:     b.pos,
+			order:   b.order,
+			hlswap:  b.hlswap,
+			errMode: b.errMode,
+		},
+	}
+}
+
+// AsBuffer returns the Builder's embedded Buffer, whose writes fail instead
+// of growing once the current capacity is used up. It is not a copy: it
+// shares the position, count and settings with b, and follows b's storage
+// when b grows.
+func (b *Builder) AsBuffer() *Buffer { return &b.Buffer }
+
+// Clone returns an independent deep copy of b, as Buffer.Clone does, that
+// also keeps b's maximum capacity and open nested messages.
+func (b *Builder) Clone() *Builder {
+	return &Builder{
+		Buffer: *b.Buffer.Clone(),
+		maxCap: b.maxCap,
+		nested: slices.Clone(b.nested),
+	}
+}
+
 // ensure ensures the underlying data slice has at least the required capacity.
-// If not, it grows the slice.
-func (b *Builder) ensure(required int) {
+// If not, it grows the slice. It reports false, without growing, if an error
+// is already recorded or the maximum capacity would be exceeded (which panics
+// unless error mode is enabled).
+func (b *Builder) ensure(required int) bool {
+	if b.err != nil {
+		return false
+	}
 	if required <= cap(b.data) {
-		return
+		return true
+	}
+	if b.maxCap > 0 && required > b.maxCap {
+		return b.fail(fmt.Errorf("mbuff.Builder.ensure: required capacity %d exceeds max capacity %d", required, b.maxCap))
 	}
 
 	var newCap int
@@ -55,11 +129,28 @@ func (b *Builder) ensure(required int) {
 			newCap = required
 		}
 	}
+	if b.maxCap > 0 && newCap > b.maxCap {
+		newCap = b.maxCap
+	}
 
 	// Allocate new slice and copy existing data
 	newData := make([]byte, len(b.data), newCap)
 	copy(newData, b.data)
 	b.data = newData
+	return true
+}
+
+// SetMaxCapacity caps how far the buffer may grow; 0 removes the cap.
+// A write that would need more capacity panics by default. With error mode
+// enabled (see SetStrictMode) it is recorded as a sticky error instead and
+// all further writes become no-ops until ClearErr, so a long sequence of
+// Put calls can be checked once via Err. Without a maximum capacity the
+// Builder always grows and its writes never fail.
+func (b *Builder) SetMaxCapacity(n int) {
+	if n < 0 {
+		panic("mbuff.Builder.SetMaxCapacity: negative capacity")
+	}
+	b.maxCap = n
 }
 
 // Grow grows the buffer's capacity to guarantee space for n more bytes.
@@ -74,6 +165,22 @@ func (b *Builder) Grow(n int) {
 	b.ensure(required)
 }
 
+// GrowZeroed is like Grow but also zeroes the whole appendable region, from
+// the count up to the new capacity. This keeps stale data in a reused or
+// pooled backing array from leaking through Claim or a partial fill.
+// Unlike Grow, it writes every appendable byte on each call, which costs
+// O(capacity - count) even when no allocation is needed.
+// If n is negative, GrowZeroed will panic.
+func (b *Builder) GrowZeroed(n int) {
+	if n < 0 {
+		panic("mbuff.Builder.GrowZeroed: negative count")
+	}
+	if !b.ensure(len(b.data) + n) {
+		return
+	}
+	clear(b.data[len(b.data):cap(b.data)])
+}
+
 // Reserve reserves space to guarantee the buffer can hold at least capacity bytes
 // without another allocation.
 // If capacity is less than or equal to current capacity, this is a no-op.
@@ -85,6 +192,49 @@ func (b *Builder) Reserve(capacity int) {
 	b.ensure(capacity)
 }
 
+// Recycle prepares b for reuse, e.g. before returning it to a sync.Pool. If
+// the capacity exceeds maxRetain, the backing array is dropped so that a
+// Builder which grew for one large message does not pin that memory; the next
+// write allocates afresh. Otherwise the array is kept and only the count and
+// position are reset. Either way any recorded error and open nested frames
+// are discarded, while the byte order, swap, failure mode and maximum
+// capacity settings are kept. Panics if maxRetain is negative.
+func (b *Builder) Recycle(maxRetain int) {
+	if maxRetain < 0 {
+		panic("mbuff.Builder.Recycle: negative max retain")
+	}
+	if cap(b.data) > maxRetain {
+		b.data = nil
+	}
+	b.Clear()
+	b.err = nil
+	b.nested = nil
+}
+
+// Claim reserves n bytes at the current position, advances the position past
+// them and returns them for the caller to fill in place. The count is
+// extended if needed. The caller must overwrite the whole returned slice,
+// which may hold stale data. The buffer will automatically grow if necessary.
+// If n is negative, Claim will panic.
+func (b *Builder) Claim(n int) []byte {
+	if n < 0 {
+		panic("mbuff.Builder.Claim: negative count")
+	}
+	required := b.pos + n
+	if !b.ensure(required) {
+		return nil
+	}
+
+	// Extend data slice if needed
+	if required > len(b.data) {
+		b.data = b.data[:required]
+	}
+
+	v := b.data[b.pos:required:required]
+	b.pos = required
+	return v
+}
+
 // Fill fills the buffer with byte b for the specified length.
 // The buffer will automatically grow if necessary.
 func (b *Builder) Fill(bt byte, length int) int {
@@ -93,7 +243,9 @@ func (b *Builder) Fill(bt byte, length int) int {
 	}
 
 	required := b.pos + length
-	b.ensure(required)
+	if !b.ensure(required) {
+		return 0
+	}
 
 	// Extend data slice if needed
 	if required > len(b.data) {
@@ -109,16 +261,32 @@ func (b *Builder) Fill(bt byte, length int) int {
 	return length
 }
 
+// ZeroFill appends length zero bytes after the current count without moving
+// the position, and returns the number of bytes appended.
+// The buffer will automatically grow if necessary.
+func (b *Builder) ZeroFill(length int) int {
+	if length <= 0 {
+		return 0
+	}
+	if !b.ensure(len(b.data) + length) {
+		return 0
+	}
+	return b.Buffer.ZeroFill(length)
+}
+
 // Write writes data from p into the buffer.
 // It implements the io.Writer interface.
 // The buffer will automatically grow if necessary to accommodate all data.
+// A nil or empty p is a no-op returning (0, nil).
 func (b *Builder) Write(p []byte) (n int, err error) {
 	if len(p) == 0 {
 		return 0, nil
 	}
 
 	required := b.pos + len(p)
-	b.ensure(required)
+	if !b.ensure(required) {
+		return 0, b.err
+	}
 
 	// Extend data slice if needed
 	if required > len(b.data) {
@@ -130,17 +298,70 @@ func (b *Builder) Write(p []byte) (n int, err error) {
 	return n, nil
 }
 
+// WriteAt writes p at offset off without moving the position, extending the
+// count if needed and zero-filling any gap after it.
+// It implements the io.WriterAt interface.
+// The buffer will automatically grow if necessary to accommodate all data.
+// A nil or empty p is a no-op returning (0, nil). Returns an error if off is
+// negative.
+func (b *Builder) WriteAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, fmt.Errorf("mbuff.Builder.WriteAt: negative offset %d: %w", off, ErrOutOfRange)
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if !b.ensure(int(off) + len(p)) {
+		return 0, b.err
+	}
+	return b.Buffer.WriteAt(p, off)
+}
+
+// WriteByte writes byte c at the current position and advances the position.
+// It implements the io.ByteWriter interface.
+// The buffer will automatically grow if necessary.
+func (b *Builder) WriteByte(c byte) error {
+	b.PutU8(c)
+	return b.err
+}
+
+// WriteString writes the contents of s at the current position and advances the position.
+// It implements the io.StringWriter interface.
+// The buffer will automatically grow if necessary.
+func (b *Builder) WriteString(s string) (n int, err error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	b.PutStr(s)
+	if b.err != nil {
+		return 0, b.err
+	}
+	return len(s), nil
+}
+
+// WriteRune writes the UTF-8 encoding of r at the current position and
+// advances the position, returning the number of bytes written.
+// The buffer will automatically grow if necessary.
+func (b *Builder) WriteRune(r rune) (n int, err error) {
+	var tmp [utf8.UTFMax]byte
+	n = utf8.EncodeRune(tmp[:], r)
+	return b.Write(tmp[:n])
+}
+
 // PutU8 writes a uint8 at the current position and advances the position.
 // The buffer will automatically grow if necessary.
 func (b *Builder) PutU8(v uint8) {
 	required := b.pos + 1
-	b.ensure(required)
+	if !b.ensure(required) {
+		return
+	}
 
 	// Extend data slice if needed
 	if required > len(b.data) {
 		b.data = b.data[:required]
 	}
 
+	b.traceOp("PutU8", 1, uint64(v))
 	b.data[b.pos] = v
 	b.pos += 1
 }
@@ -149,13 +370,16 @@ func (b *Builder) PutU8(v uint8) {
 // The buffer will automatically grow if necessary.
 func (b *Builder) PutU16(v uint16) {
 	required := b.pos + 2
-	b.ensure(required)
+	if !b.ensure(required) {
+		return
+	}
 
 	// Extend data slice if needed
 	if required > len(b.data) {
 		b.data = b.data[:required]
 	}
 
+	b.traceOp("PutU16", 2, uint64(v))
 	b.order.PutUint16(b.data[b.pos:], v)
 	b.pos += 2
 }
@@ -164,13 +388,16 @@ func (b *Builder) PutU16(v uint16) {
 // The buffer will automatically grow if necessary.
 func (b *Builder) PutU32(v uint32) {
 	required := b.pos + 4
-	b.ensure(required)
+	if !b.ensure(required) {
+		return
+	}
 
 	// Extend data slice if needed
 	if required > len(b.data) {
 		b.data = b.data[:required]
 	}
 
+	b.traceOp("PutU32", 4, uint64(v))
 	b.order.PutUint32(b.data[b.pos:], b.HLSwap32(v))
 	b.pos += 4
 }
@@ -179,44 +406,111 @@ func (b *Builder) PutU32(v uint32) {
 // The buffer will automatically grow if necessary.
 func (b *Builder) PutU64(v uint64) {
 	required := b.pos + 8
-	b.ensure(required)
+	if !b.ensure(required) {
+		return
+	}
 
 	// Extend data slice if needed
 	if required > len(b.data) {
 		b.data = b.data[:required]
 	}
 
+	b.traceOp("PutU64", 8, v)
 	b.order.PutUint64(b.data[b.pos:], b.HLSwap64(v))
 	b.pos += 8
 }
 
+// PutI8 writes an int8 at the current position and advances the position.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutI8(v int8) { b.PutU8(uint8(v)) }
+
+// PutI16 writes an int16 as its two's complement uint16 at the current
+// position and advances the position.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutI16(v int16) { b.PutU16(uint16(v)) }
+
+// PutI32 writes an int32 as its two's complement uint32 at the current
+// position and advances the position.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutI32(v int32) { b.PutU32(uint32(v)) }
+
+// PutI64 writes an int64 as its two's complement uint64 at the current
+// position and advances the position.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutI64(v int64) { b.PutU64(uint64(v)) }
+
+// PutBool writes v as one byte, 0x01 for true and 0x00 for false, at the
+// current position and advances the position.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutBool(v bool) { b.PutU8(boolByte(v)) }
+
+// PutF32 writes the IEEE-754 bits of a float32 at the current position and
+// advances the position.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutF32(v float32) { b.PutU32(math.Float32bits(v)) }
+
+// PutF64 writes the IEEE-754 bits of a float64 at the current position and
+// advances the position.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutF64(v float64) { b.PutU64(math.Float64bits(v)) }
+
 // PutArr8 writes a byte slice at the current position and advances the position.
 // The buffer will automatically grow if necessary.
 func (b *Builder) PutArr8(v []byte) {
 	required := b.pos + len(v)
-	b.ensure(required)
+	if !b.ensure(required) {
+		return
+	}
 
 	// Extend data slice if needed
 	if required > len(b.data) {
 		b.data = b.data[:required]
 	}
 
+	b.traceOp("PutArr8", len(v), 0)
 	n := copy(b.data[b.pos:], v)
 	b.pos += n
 }
 
+// PutStr writes the raw bytes of s at the current position and advances the position.
+// No length prefix is written. The buffer will automatically grow if necessary.
+func (b *Builder) PutStr(s string) {
+	required := b.pos + len(s)
+	if !b.ensure(required) {
+		return
+	}
+
+	// Extend data slice if needed
+	if required > len(b.data) {
+		b.data = b.data[:required]
+	}
+
+	b.traceOp("PutStr", len(s), 0)
+	n := copy(b.data[b.pos:], s)
+	b.pos += n
+}
+
 // PutArr16 writes a uint16 slice at the current position and advances the position.
 // The buffer will automatically grow if necessary.
 func (b *Builder) PutArr16(v []uint16) {
 	byteLen := len(v) << 1
 	required := b.pos + byteLen
-	b.ensure(required)
+	if !b.ensure(required) {
+		return
+	}
 
 	// Extend data slice if needed
 	if required > len(b.data) {
 		b.data = b.data[:required]
 	}
 
+	b.traceOp("PutArr16", byteLen, 0)
+	if b.isHostOrder() {
+		copy(b.data[b.pos:], bytesOf16(v))
+		b.pos += byteLen
+		return
+	}
+
 	writePos := b.pos
 	for _, val := range v {
 		b.order.PutUint16(b.data[writePos:], val)
@@ -230,13 +524,22 @@ func (b *Builder) PutArr16(v []uint16) {
 func (b *Builder) PutArr32(v []uint32) {
 	byteLen := len(v) << 2
 	required := b.pos + byteLen
-	b.ensure(required)
+	if !b.ensure(required) {
+		return
+	}
 
 	// Extend data slice if needed
 	if required > len(b.data) {
 		b.data = b.data[:required]
 	}
 
+	b.traceOp("PutArr32", byteLen, 0)
+	if b.isHostLayout() {
+		copy(b.data[b.pos:], bytesOf32(v))
+		b.pos += byteLen
+		return
+	}
+
 	writePos := b.pos
 	for _, val := range v {
 		b.order.PutUint32(b.data[writePos:], b.HLSwap32(val))
@@ -250,13 +553,22 @@ func (b *Builder) PutArr32(v []uint32) {
 func (b *Builder) PutArr64(v []uint64) {
 	byteLen := len(v) << 3
 	required := b.pos + byteLen
-	b.ensure(required)
+	if !b.ensure(required) {
+		return
+	}
 
 	// Extend data slice if needed
 	if required > len(b.data) {
 		b.data = b.data[:required]
 	}
 
+	b.traceOp("PutArr64", byteLen, 0)
+	if b.isHostLayout() {
+		copy(b.data[b.pos:], bytesOf64(v))
+		b.pos += byteLen
+		return
+	}
+
 	writePos := b.pos
 	for _, val := range v {
 		b.order.PutUint64(b.data[writePos:], b.HLSwap64(val))
@@ -264,3 +576,574 @@ func (b *Builder) PutArr64(v []uint64) {
 	}
 	b.pos += byteLen
 }
+
+// PatchU8 writes a uint8 at the specified offset, extending the count if needed.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PatchU8(offset int, v uint8) {
+	b.ensure(offset + 1)
+	b.Buffer.PatchU8(offset, v)
+}
+
+// PatchU16 writes a uint16 at the specified offset, extending the count if needed.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PatchU16(offset int, v uint16) {
+	b.ensure(offset + 2)
+	b.Buffer.PatchU16(offset, v)
+}
+
+// PatchU32 writes a uint32 at the specified offset, extending the count if needed.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PatchU32(offset int, v uint32) {
+	b.ensure(offset + 4)
+	b.Buffer.PatchU32(offset, v)
+}
+
+// PatchU64 writes a uint64 at the specified offset, extending the count if needed.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PatchU64(offset int, v uint64) {
+	b.ensure(offset + 8)
+	b.Buffer.PatchU64(offset, v)
+}
+
+// PatchArr8 writes bytes at the specified offset, extending the count if needed.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PatchArr8(offset int, v []byte) {
+	b.ensure(offset + len(v))
+	b.Buffer.PatchArr8(offset, v)
+}
+
+// PatchArr16 writes uint16 values at the specified offset, extending the count if needed.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PatchArr16(offset int, v []uint16) {
+	b.ensure(offset + len(v)<<1)
+	b.Buffer.PatchArr16(offset, v)
+}
+
+// PatchArr32 writes uint32 values at the specified offset, extending the count if needed.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PatchArr32(offset int, v []uint32) {
+	b.ensure(offset + len(v)<<2)
+	b.Buffer.PatchArr32(offset, v)
+}
+
+// PatchArr64 writes uint64 values at the specified offset, extending the count if needed.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PatchArr64(offset int, v []uint64) {
+	b.ensure(offset + len(v)<<3)
+	b.Buffer.PatchArr64(offset, v)
+}
+
+// PutArrF32 writes a float32 slice at the current position and advances the position.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutArrF32(v []float32) { b.PutArr32(reinterpret[uint32](v)) }
+
+// PutArrF64 writes a float64 slice at the current position and advances the position.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutArrF64(v []float64) { b.PutArr64(reinterpret[uint64](v)) }
+
+// PutArr32Func writes each element of v transformed by fn at the current
+// position and advances the position.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutArr32Func(v []uint32, fn func(uint32) uint32) {
+	b.ensure(b.pos + len(v)<<2)
+	b.Buffer.PutArr32Func(v, fn)
+}
+
+// InterleaveArr32 writes the sources record by record at the current position
+// and advances the position. See Buffer.InterleaveArr32 for the layout.
+// The buffer will automatically grow if necessary.
+func (b *Builder) InterleaveArr32(stride int, sources ...[]uint32) error {
+	records, err := interleavedLen(stride, sources)
+	if err != nil {
+		return err
+	}
+	b.ensure(b.pos + (records*stride*len(sources))<<2)
+	return b.Buffer.InterleaveArr32(stride, sources...)
+}
+
+// PutSizedBytes writes v preceded by the smallest length prefix that fits,
+// then advances the position. See Buffer.PutSizedBytes for the scheme.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutSizedBytes(v []byte) {
+	b.ensure(b.pos + sizedPrefixLen(len(v)) + len(v))
+	b.Buffer.PutSizedBytes(v)
+}
+
+// PutTLVList writes records at the current position and advances the position.
+// See Buffer.PutTLVList for the layout. The total size is computed up front so
+// the buffer grows at most once.
+func (b *Builder) PutTLVList(width int, records []TLV) error {
+	total, err := tlvListLen("Builder.PutTLVList", width, records)
+	if err != nil {
+		return err
+	}
+	b.ensure(b.pos + total)
+	return b.Buffer.PutTLVList(width, records)
+}
+
+// PutFixedQ writes value as a signed Q(intBits.fracBits) fixed-point number,
+// then advances the position. See Buffer.PutFixedQ for the encoding.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutFixedQ(value float64, intBits, fracBits int) {
+	width := fixedQWidth("Builder.PutFixedQ", intBits, fracBits)
+	raw := toFixedQ(value, fracBits, width)
+	switch width {
+	case 8:
+		b.PutU8(uint8(raw))
+	case 16:
+		b.PutU16(uint16(raw))
+	case 32:
+		b.PutU32(uint32(raw))
+	default:
+		b.PutU64(uint64(raw))
+	}
+}
+
+// PutVLQ writes v as a MIDI-style variable-length quantity and advances the
+// position. See Buffer.PutVLQ for the encoding.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutVLQ(v uint32) {
+	b.ensure(b.pos + vlqLen(v))
+	b.Buffer.PutVLQ(v)
+}
+
+// ReadFrom reads data from r until io.EOF and writes it at the current
+// position, advancing the position. It implements the io.ReaderFrom
+// interface. The return value n is the number of bytes read; io.EOF is not
+// returned, but any other error from r is, after the bytes read before it
+// have been written. Each read goes into the free capacity after the valid
+// data and is then copied to the position, so r never sees valid bytes it
+// could clobber. The buffer will automatically grow if necessary.
+func (b *Builder) ReadFrom(r io.Reader) (n int64, err error) {
+	for {
+		count := len(b.data)
+		required := count + minRead
+		if b.maxCap > 0 && required > b.maxCap {
+			// Read up to the limit; fail only once no space is left.
+			required = max(b.maxCap, count+1)
+		}
+		if !b.ensure(required) {
+			return n, b.err
+		}
+		free := b.data[count:cap(b.data)]
+		m, err := r.Read(free)
+		if m < 0 {
+			panic("mbuff.Builder.ReadFrom: reader returned negative count")
+		}
+		end := b.pos + m
+		copy(b.data[b.pos:end:cap(b.data)], free[:m])
+		b.data = b.data[:max(count, end)]
+		b.pos = end
+		n += int64(m)
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+	}
+}
+
+// ReadUntilFrom returns the readable bytes up to and including the first
+// delim, reading more from r into the buffer as needed, then advances the
+// position past it. Bytes read beyond the delimiter stay buffered for the
+// next call. The returned slice aliases the buffer and is only valid until
+// the next modification. Processed bytes are not discarded; call Compact
+// periodically to bound the buffer size.
+// If r reaches EOF first, the remaining readable bytes are returned with
+// io.EOF. If no delimiter is found within limit bytes, an error is returned
+// and nothing is consumed. If r keeps returning no data and no error,
+// io.ErrNoProgress is returned.
+func (b *Builder) ReadUntilFrom(r io.Reader, delim byte, limit int) ([]byte, error) {
+	if limit <= 0 {
+		panic("mbuff.Builder.ReadUntilFrom: non-positive limit")
+	}
+	scanned, empty := 0, 0
+	var err error
+	for {
+		readable := b.data[b.pos:]
+		if len(readable) > limit {
+			readable = readable[:limit]
+		}
+		if i := bytes.IndexByte(readable[scanned:], delim); i >= 0 {
+			n := scanned + i + 1
+			line := b.data[b.pos : b.pos+n]
+			b.pos += n
+			return line, nil
+		}
+		scanned = len(readable)
+		if scanned >= limit {
+			return nil, fmt.Errorf("mbuff.Builder.ReadUntilFrom: delimiter not found within %d bytes", limit)
+		}
+		if err == io.EOF {
+			line := b.data[b.pos:]
+			b.pos = len(b.data)
+			return line, io.EOF
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var n int
+		required := len(b.data) + minRead
+		if b.maxCap > 0 && required > b.maxCap {
+			// Read up to the limit; fail only once no space is left.
+			required = max(b.maxCap, len(b.data)+1)
+		}
+		if !b.ensure(required) {
+			return nil, b.err
+		}
+		n, err = r.Read(b.data[len(b.data):cap(b.data)])
+		if n < 0 {
+			panic("mbuff.Builder.ReadUntilFrom: reader returned negative count")
+		}
+		b.data = b.data[:len(b.data)+n]
+		if n > 0 || err != nil {
+			empty = 0
+		} else if empty++; empty >= maxEmptyReads {
+			return nil, io.ErrNoProgress
+		}
+	}
+}
+
+// StreamFrom reads total bytes from r into the buffer at the current position
+// in chunks of at most chunkSize bytes, advancing the position past each
+// chunk. The buffer grows one chunk at a time, so a large total is never
+// reserved up front. It returns the number of bytes read, which is less than
+// total only with an error: io.ErrUnexpectedEOF if r ends early, the error
+// from r, or the recorded error if the maximum capacity is reached in error
+// mode. Bytes read before the error stay written.
+// Panics if total is negative or chunkSize is not positive.
+func (b *Builder) StreamFrom(r io.Reader, total, chunkSize int) (int, error) {
+	if total < 0 {
+		panic("mbuff.Builder.StreamFrom: negative total")
+	}
+	if chunkSize <= 0 {
+		panic("mbuff.Builder.StreamFrom: non-positive chunk size")
+	}
+	read := 0
+	for read < total {
+		k := min(chunkSize, total-read)
+		if !b.ensure(b.pos + k) {
+			return read, b.err
+		}
+		count := len(b.data)
+		if b.pos+k > count {
+			b.data = b.data[:b.pos+k]
+		}
+		n, err := io.ReadFull(r, b.data[b.pos:b.pos+k])
+		b.pos += n
+		read += n
+		b.data = b.data[:max(count, b.pos)] // drop the unread tail of a short chunk
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return read, err
+		}
+	}
+	return read, nil
+}
+
+// PutSliceU32 writes v as a u32 element count followed by the elements,
+// then advances the position. The buffer will automatically grow if necessary.
+func (b *Builder) PutSliceU32(v []uint32) {
+	b.ensure(b.pos + 4 + len(v)<<2)
+	b.Buffer.PutSliceU32(v)
+}
+
+// PutMapU32U32 writes m as a u32 entry count followed by key/value pairs,
+// then advances the position. See Buffer.PutMapU32U32 for the ordering.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutMapU32U32(m map[uint32]uint32) {
+	b.ensure(b.pos + 4 + len(m)<<3)
+	b.Buffer.PutMapU32U32(m)
+}
+
+// PutEnumU8 writes v at the current position if it is one of the allowed
+// values, then advances the position. Nothing is written otherwise.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutEnumU8(v uint8, valid []uint8) error {
+	if !slices.Contains(valid, v) {
+		return fmt.Errorf("mbuff.Builder.PutEnumU8: unknown value %d", v)
+	}
+	b.ensure(b.pos + 1)
+	return b.Buffer.PutEnumU8(v, valid)
+}
+
+// PutEnumU16 writes v at the current position if it is one of the allowed
+// values, then advances the position. Nothing is written otherwise.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutEnumU16(v uint16, valid []uint16) error {
+	if !slices.Contains(valid, v) {
+		return fmt.Errorf("mbuff.Builder.PutEnumU16: unknown value %d", v)
+	}
+	b.ensure(b.pos + 2)
+	return b.Buffer.PutEnumU16(v, valid)
+}
+
+// AppendAdler32 writes the Adler-32 checksum of [start, pos) as a uint32 at
+// the current position and advances the position.
+// The buffer will automatically grow if necessary.
+func (b *Builder) AppendAdler32(start int) {
+	b.ensure(b.pos + 4)
+	b.Buffer.AppendAdler32(start)
+}
+
+// AppendFletcher16 writes the Fletcher-16 checksum of [start, pos) as a
+// uint16 at the current position and advances the position.
+// The buffer will automatically grow if necessary.
+func (b *Builder) AppendFletcher16(start int) {
+	b.ensure(b.pos + 2)
+	b.Buffer.AppendFletcher16(start)
+}
+
+// AppendFletcher32 writes the Fletcher-32 checksum of [start, pos) as a
+// uint32 at the current position and advances the position.
+// The buffer will automatically grow if necessary.
+func (b *Builder) AppendFletcher32(start int) {
+	b.ensure(b.pos + 4)
+	b.Buffer.AppendFletcher32(start)
+}
+
+// AppendSumChecksum8 writes the 8-bit additive checksum of [start, pos), or
+// its two's complement if negate is set, and advances the position.
+// The buffer will automatically grow if necessary.
+func (b *Builder) AppendSumChecksum8(start int, negate bool) {
+	b.ensure(b.pos + 1)
+	b.Buffer.AppendSumChecksum8(start, negate)
+}
+
+// AppendSumChecksum16 writes the 16-bit additive checksum of [start, pos), or
+// its two's complement if negate is set, as a uint16 and advances the position.
+// The buffer will automatically grow if necessary.
+func (b *Builder) AppendSumChecksum16(start int, negate bool) {
+	b.ensure(b.pos + 2)
+	b.Buffer.AppendSumChecksum16(start, negate)
+}
+
+// Marshal writes the exported fields of the struct v (or pointer to struct),
+// then advances the position. See Buffer.Marshal for the encoding.
+// The buffer will automatically grow if necessary.
+func (b *Builder) Marshal(v any) error {
+	return b.marshal("Builder.Marshal", v, b.PutArr8)
+}
+
+// PutU16In writes a uint16 in byte order e at the current position and advances
+// the position, without changing the buffer's byte order.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutU16In(v uint16, e Endian) {
+	b.ensure(b.pos + 2)
+	b.Buffer.PutU16In(v, e)
+}
+
+// PutU32In writes a uint32 in byte order e at the current position and advances
+// the position, without changing the buffer's byte order. High-low swap still applies.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutU32In(v uint32, e Endian) {
+	b.ensure(b.pos + 4)
+	b.Buffer.PutU32In(v, e)
+}
+
+// PutU64In writes a uint64 in byte order e at the current position and advances
+// the position, without changing the buffer's byte order. High-low swap still applies.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutU64In(v uint64, e Endian) {
+	b.ensure(b.pos + 8)
+	b.Buffer.PutU64In(v, e)
+}
+
+// PutU16LE writes a little-endian uint16 at the current position and
+// advances the position, regardless of the buffer's byte order.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutU16LE(v uint16) { b.PutU16In(v, LittleEndian) }
+
+// PutU16BE writes a big-endian uint16 at the current position and
+// advances the position, regardless of the buffer's byte order.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutU16BE(v uint16) { b.PutU16In(v, BigEndian) }
+
+// PutU32LE writes a little-endian uint32 at the current position and
+// advances the position, regardless of the buffer's byte order. High-low swap still applies.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutU32LE(v uint32) { b.PutU32In(v, LittleEndian) }
+
+// PutU32BE writes a big-endian uint32 at the current position and
+// advances the position, regardless of the buffer's byte order. High-low swap still applies.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutU32BE(v uint32) { b.PutU32In(v, BigEndian) }
+
+// PutU64LE writes a little-endian uint64 at the current position and
+// advances the position, regardless of the buffer's byte order. High-low swap still applies.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutU64LE(v uint64) { b.PutU64In(v, LittleEndian) }
+
+// PutU64BE writes a big-endian uint64 at the current position and
+// advances the position, regardless of the buffer's byte order. High-low swap still applies.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutU64BE(v uint64) { b.PutU64In(v, BigEndian) }
+
+// Insert splices data into the valid data at offset, shifting [offset:len]
+// right by len(data). See Buffer.Insert. Open nested messages move with their
+// bytes. Panics if offset falls inside the length prefix of an open nested
+// message, unless error mode is enabled.
+// The buffer will automatically grow if necessary.
+func (b *Builder) Insert(offset int, data []byte) {
+	if !b.checkNestedSplice("Insert", offset, offset) {
+		return
+	}
+	b.ensure(len(b.data) + len(data))
+	count := len(b.data)
+	b.Buffer.Insert(offset, data)
+	if len(b.data) != count {
+		b.moveNested(insertMove(offset, len(data)))
+	}
+}
+
+// Delete removes the n bytes at offset from the valid data, shifting the
+// tail left. See Buffer.Delete. Open nested messages move with their bytes.
+// Panics if the range overlaps the length prefix of an open nested message,
+// unless error mode is enabled.
+func (b *Builder) Delete(offset, n int) {
+	if !b.checkNestedSplice("Delete", offset, offset+n) {
+		return
+	}
+	count := len(b.data)
+	b.Buffer.Delete(offset, n)
+	if len(b.data) != count {
+		b.moveNested(deleteMove(offset, n))
+	}
+}
+
+// EncodeBase32 writes src encoded with enc at the current position and
+// advances the position. See Buffer.EncodeBase32.
+// The buffer will automatically grow if necessary.
+func (b *Builder) EncodeBase32(enc *base32.Encoding, src []byte) {
+	b.ensure(b.pos + enc.EncodedLen(len(src)))
+	b.Buffer.EncodeBase32(enc, src)
+}
+
+// EncodeCOBS writes src with Consistent Overhead Byte Stuffing followed by a
+// zero delimiter, and advances the position. See Buffer.EncodeCOBS.
+// The buffer will automatically grow if necessary.
+func (b *Builder) EncodeCOBS(src []byte) {
+	b.ensure(b.pos + cobsMaxLen(len(src)))
+	b.Buffer.EncodeCOBS(src)
+}
+
+// EncodeCOBSR writes src with the reduced COBS/R variant followed by a zero
+// delimiter, and advances the position. See Buffer.EncodeCOBSR.
+// The buffer will automatically grow if necessary.
+func (b *Builder) EncodeCOBSR(src []byte) {
+	b.ensure(b.pos + cobsMaxLen(len(src)))
+	b.Buffer.EncodeCOBSR(src)
+}
+
+// PutBitsArr writes v as a bit-packed array of nbits-wide values and
+// advances the position. See Buffer.PutBitsArr for the layout.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutBitsArr(nbits int, v []uint32) {
+	checkBitWidth("Builder.PutBitsArr", nbits)
+	if err := checkBitsValues("Builder.PutBitsArr", nbits, v); err != nil {
+		b.fail(err)
+		return
+	}
+	b.ensure(b.pos + bitsLen(nbits, len(v)))
+	b.putBitsArr(nbits, v)
+}
+
+// EncodeHDLC writes payload as an HDLC-style frame and advances the position.
+// See Buffer.EncodeHDLC for the stuffing rules.
+// The buffer will automatically grow if necessary.
+func (b *Builder) EncodeHDLC(payload []byte) {
+	b.ensure(b.pos + hdlcLen(payload))
+	b.Buffer.EncodeHDLC(payload)
+}
+
+// PutUTF16String writes s encoded as UTF-16, preceded by its length in code
+// units as a uint16, then advances the position. See Buffer.PutUTF16String.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutUTF16String(s string) {
+	units := utf16.Encode([]rune(s))
+	if err := checkUTF16Len("Builder.PutUTF16String", len(units)); err != nil {
+		b.fail(err)
+		return
+	}
+	b.ensure(b.pos + 2 + len(units)<<1)
+	b.Buffer.putUTF16("PutUTF16String", units)
+}
+
+// WriteCompressedZeros writes p with runs of zeros compressed, then advances
+// the position. See Buffer.WriteCompressedZeros for the format.
+// The buffer will automatically grow if necessary.
+func (b *Builder) WriteCompressedZeros(p []byte) {
+	b.ensure(b.pos + zrleEncode(nil, p))
+	b.Buffer.WriteCompressedZeros(p)
+}
+
+// PutFieldTag writes a protobuf field tag, the uvarint of
+// fieldNum<<3 | wireType, and advances the position.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutFieldTag(fieldNum, wireType int) {
+	if checkFieldTag("Builder.PutFieldTag", fieldNum, wireType) == nil {
+		b.ensure(b.pos + UvarintLen(uint64(fieldNum)<<3))
+	}
+	b.Buffer.PutFieldTag(fieldNum, wireType)
+}
+
+// PutCoord writes degrees as round(degrees * DefaultCoordScale) in a
+// two's complement int32, then advances the position.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutCoord(degrees float64) {
+	b.PutI32(toCoord(degrees, DefaultCoordScale))
+}
+
+// PutCoordScaled writes degrees as round(degrees * scale) in a two's
+// complement int32, then advances the position. See Buffer.PutCoordScaled.
+// The buffer will automatically grow if necessary.
+// Panics if scale is not positive and finite.
+func (b *Builder) PutCoordScaled(degrees, scale float64) {
+	checkCoordScale("Builder.PutCoordScaled", scale)
+	b.PutI32(toCoord(degrees, scale))
+}
+
+// PutLatLon writes lat then lon as PutCoord does and advances the position.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutLatLon(lat, lon float64) {
+	b.ensure(b.pos + 8)
+	b.Buffer.PutLatLon(lat, lon)
+}
+
+// PutProtectedLength writes n as a uint32 followed by a one-byte check of
+// those 4 bytes and advances the position. See Buffer.PutProtectedLength.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutProtectedLength(n uint32) {
+	b.ensure(b.pos + 5)
+	b.Buffer.PutProtectedLength(n)
+}
+
+// PutU24 writes the 3-byte unsigned integer v at the current position and
+// advances the position. See Buffer.PutU24.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutU24(v uint32) {
+	if v <= MaxU24 {
+		b.ensure(b.pos + 3)
+	}
+	b.Buffer.PutU24(v)
+}
+
+// PutUvarint writes v as an unsigned LEB128 varint and advances the
+// position. It returns the number of bytes written.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutUvarint(v uint64) int {
+	b.ensure(b.pos + UvarintLen(v))
+	return b.Buffer.PutUvarint(v)
+}
+
+// PutVarint writes v as a zig-zag LEB128 varint and advances the position.
+// It returns the number of bytes written.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutVarint(v int64) int {
+	b.ensure(b.pos + VarintLen(v))
+	return b.Buffer.PutVarint(v)
+}
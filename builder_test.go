@@ -7,8 +7,11 @@ package mbuff
 
 import (
 	"encoding/binary"
+	"fmt"
 	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -613,3 +616,521 @@ func TestBuilder_PutArr(t *testing.T) {
 		assert.Equal(t, expected, b.Bytes())
 	})
 }
+
+// TestBuilder_Patch tests that positioned writes grow the buffer.
+func TestBuilder_Patch(t *testing.T) {
+	b := NewBuilder(4)
+	b.PutU16(0x0102)
+
+	b.PatchU32(6, 0x03040506)
+	assert.Equal(t, []byte{0x01, 0x02, 0x00, 0x00, 0x00, 0x00, 0x03, 0x04, 0x05, 0x06}, b.Bytes())
+	assert.Equal(t, 2, b.Pos())
+
+	b.PatchU64(100, 0x0708090A0B0C0D0E)
+	assert.Equal(t, 108, b.Count())
+	assert.GreaterOrEqual(t, b.Capacity(), 108)
+	assert.Equal(t, uint64(0x0708090A0B0C0D0E), b.PeekAbsU64(100))
+
+	b.PatchArr64(108, []uint64{1, 2})
+	b.PatchArr8(0, []byte{0xAA})
+	b.PatchU8(1, 0xBB)
+	b.PatchU16(2, 0xCCDD)
+	b.PatchArr16(124, []uint16{0x1234})
+	b.PatchArr32(126, []uint32{0x56789ABC})
+	assert.Equal(t, 130, b.Count())
+	assert.Equal(t, uint32(0xAABBCCDD), b.PeekAbsU32(0))
+	assert.Equal(t, uint16(0x1234), b.PeekAbsU16(124))
+
+	assert.Panics(t, func() { b.PatchU8(-1, 0x00) })
+}
+
+// TestBuilder_InterleaveArr32 tests that interleaved writes grow the buffer.
+func TestBuilder_InterleaveArr32(t *testing.T) {
+	b := NewBuilder(0)
+	assert.NoError(t, b.InterleaveArr32(2, []uint32{1, 2, 3, 4}, []uint32{5, 6, 7, 8}, []uint32{9, 10, 11, 12}))
+	assert.Equal(t, 48, b.Count())
+
+	out := make([]uint32, 12)
+	b.Rewind()
+	b.TakeArr32(out)
+	assert.Equal(t, []uint32{1, 2, 5, 6, 9, 10, 3, 4, 7, 8, 11, 12}, out)
+
+	assert.Error(t, b.InterleaveArr32(2, []uint32{1, 2, 3}))
+	assert.Equal(t, 48, b.Count())
+}
+
+// TestBuilder_ReadUntilFrom tests delimiter-based ingestion from a reader.
+func TestBuilder_ReadUntilFrom(t *testing.T) {
+	// Delimiters straddling read boundaries
+	r := iotest.OneByteReader(strings.NewReader("first\nsecond\nrest"))
+	b := NewBuilder(0)
+
+	line, err := b.ReadUntilFrom(r, '\n', 64)
+	assert.NoError(t, err)
+	assert.Equal(t, "first\n", string(line))
+
+	line, err = b.ReadUntilFrom(r, '\n', 64)
+	assert.NoError(t, err)
+	assert.Equal(t, "second\n", string(line))
+
+	line, err = b.ReadUntilFrom(r, '\n', 64)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, "rest", string(line))
+
+	// Bytes read past the delimiter stay buffered
+	b = NewBuilder(0)
+	r = iotest.DataErrReader(strings.NewReader("a\nb\n"))
+	line, err = b.ReadUntilFrom(r, '\n', 64)
+	assert.NoError(t, err)
+	assert.Equal(t, "a\n", string(line))
+	assert.Equal(t, 2, b.Readable())
+	line, err = b.ReadUntilFrom(r, '\n', 64)
+	assert.NoError(t, err)
+	assert.Equal(t, "b\n", string(line))
+
+	// Limit
+	b = NewBuilder(0)
+	r = strings.NewReader("0123456789\n")
+	_, err = b.ReadUntilFrom(r, '\n', 8)
+	assert.Error(t, err)
+	assert.Equal(t, 0, b.Pos())
+	line, err = b.ReadUntilFrom(r, '\n', 11)
+	assert.NoError(t, err)
+	assert.Equal(t, "0123456789\n", string(line))
+
+	// Reader errors are passed through
+	b = NewBuilder(0)
+	_, err = b.ReadUntilFrom(iotest.ErrReader(io.ErrUnexpectedEOF), '\n', 8)
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+
+	// Reads stop at the maximum capacity
+	b = NewBuilder(0)
+	b.SetMaxCapacity(256)
+	line, err = b.ReadUntilFrom(strings.NewReader("hello\n"), '\n', 64)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello\n", string(line))
+
+	b = NewBuilder(0)
+	b.SetMaxCapacity(4)
+	b.SetStrictMode(true)
+	_, err = b.ReadUntilFrom(strings.NewReader("hello\n"), '\n', 64)
+	assert.Error(t, err)
+	assert.Equal(t, 4, b.Count())
+
+	// Readers that never make progress
+	b = NewBuilder(0)
+	_, err = b.ReadUntilFrom(emptyReader{}, '\n', 8)
+	assert.Equal(t, io.ErrNoProgress, err)
+
+	assert.Panics(t, func() { _, _ = b.ReadUntilFrom(r, '\n', 0) })
+}
+
+// emptyReader returns no data and no error.
+type emptyReader struct{}
+
+func (emptyReader) Read([]byte) (int, error) { return 0, nil }
+
+// scribbleReader returns its contents in one read, after filling the whole
+// read buffer with garbage as io.Reader permits.
+type scribbleReader string
+
+func (s scribbleReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = '!'
+	}
+	return copy(p, s), io.EOF
+}
+
+// TestBuilder_ReadFrom tests reading a whole stream into a Builder.
+func TestBuilder_ReadFrom(t *testing.T) {
+	var _ io.ReaderFrom = (*Builder)(nil)
+
+	long := strings.Repeat("0123456789", 200)
+	b := NewBuilder(0)
+	b.PutU8('>')
+	n, err := b.ReadFrom(iotest.HalfReader(strings.NewReader(long)))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(long)), n)
+	assert.Equal(t, ">"+long, b.String())
+	assert.Equal(t, b.Count(), b.Pos())
+
+	// Data returned together with io.EOF, and io.Copy
+	b = NewBuilder(0)
+	n, err = b.ReadFrom(iotest.DataErrReader(strings.NewReader("abc")))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	n, err = io.Copy(b, strings.NewReader("def"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	assert.Equal(t, "abcdef", b.String())
+
+	// Writing in the middle overwrites and keeps a longer count
+	b.Seek(1)
+	_, err = b.ReadFrom(strings.NewReader("XY"))
+	assert.NoError(t, err)
+	assert.Equal(t, "aXYdef", b.String())
+	assert.Equal(t, 3, b.Pos())
+
+	// A reader using its whole buffer as scratch cannot clobber valid data
+	b.Seek(1)
+	_, err = b.ReadFrom(scribbleReader("b"))
+	assert.NoError(t, err)
+	assert.Equal(t, "abYdef", b.String())
+	assert.Equal(t, 2, b.Pos())
+
+	// Other errors are returned with the bytes read so far
+	b = NewBuilder(0)
+	n, err = b.ReadFrom(io.MultiReader(strings.NewReader("ab"), iotest.ErrReader(io.ErrClosedPipe)))
+	assert.Equal(t, io.ErrClosedPipe, err)
+	assert.Equal(t, int64(2), n)
+	assert.Equal(t, "ab", b.String())
+
+	b = NewBuilder(0)
+	b.SetMaxCapacity(100)
+	b.SetStrictMode(true)
+	n, err = b.ReadFrom(strings.NewReader(long))
+	assert.Error(t, err)
+	assert.Equal(t, int64(100), n)
+	assert.Equal(t, long[:100], b.String())
+}
+
+// TestBuilder_StreamFrom tests chunked ingestion from a reader.
+func TestBuilder_StreamFrom(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutU8(0xAA)
+	n, err := b.StreamFrom(strings.NewReader("0123456789abc"), 10, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, n)
+	assert.Equal(t, 11, b.Pos())
+	assert.Equal(t, "\xAA0123456789", b.String())
+
+	// Growth follows the chunks rather than the total
+	b = NewBuilder(0)
+	n, err = b.StreamFrom(iotest.OneByteReader(strings.NewReader(strings.Repeat("x", 80))), 1<<30, 16)
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+	assert.Equal(t, 80, n)
+	assert.Equal(t, 128, b.Capacity())
+
+	// Overwriting in the middle keeps the count
+	b.Seek(2)
+	_, err = b.StreamFrom(strings.NewReader("yy"), 2, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 80, b.Count())
+	assert.Equal(t, "xxyyx", b.String()[:5])
+
+	// Short readers keep what was read
+	b = NewBuilder(0)
+	n, err = b.StreamFrom(strings.NewReader("abcde"), 8, 3)
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, "abcde", b.String())
+	assert.Equal(t, 5, b.Pos())
+	n, err = b.StreamFrom(iotest.ErrReader(io.ErrClosedPipe), 8, 3)
+	assert.Equal(t, io.ErrClosedPipe, err)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, 5, b.Count())
+
+	// Maximum capacity in error mode
+	b = NewBuilder(0)
+	b.SetMaxCapacity(6)
+	b.SetStrictMode(true)
+	n, err = b.StreamFrom(strings.NewReader("abcdefgh"), 8, 4)
+	assert.Error(t, err)
+	assert.Equal(t, 4, n)
+
+	n, err = NewBuilder(0).StreamFrom(nil, 0, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.Panics(t, func() { _, _ = b.StreamFrom(nil, -1, 1) })
+	assert.Panics(t, func() { _, _ = b.StreamFrom(nil, 1, 0) })
+}
+
+// TestBuilder_MaxCapacity tests capping growth in panic and error mode.
+func TestBuilder_MaxCapacity(t *testing.T) {
+	b := NewBuilder(4)
+	b.SetMaxCapacity(10)
+	b.PutU64(0x0102030405060708)
+	b.PutU16(0x090A)
+	assert.Equal(t, 10, b.Capacity()) // growth is clamped to the cap
+	assert.Panics(t, func() { b.PutU8(0) })
+	assert.Equal(t, 10, b.Count())
+
+	// Error mode: first failure is sticky and later writes are no-ops
+	b = NewBuilder(0)
+	b.SetMaxCapacity(8)
+	b.SetStrictMode(true)
+	b.PutU32(0x01020304)
+	b.PutU64(0x0506070809101112)
+	b.PutU8(0x05)
+	b.PutArr8([]byte{0x06})
+	assert.Error(t, b.Err())
+	assert.Equal(t, []byte{0x01, 0x02, 0x03, 0x04}, b.Bytes())
+
+	n, err := b.Write([]byte{0x07})
+	assert.Equal(t, 0, n)
+	assert.Error(t, err)
+	assert.Equal(t, 4, b.Count())
+
+	b.ClearErr()
+	b.PutU32(0x05060708)
+	assert.NoError(t, b.Err())
+	assert.Equal(t, 8, b.Count())
+
+	// Removing the cap allows growth again
+	b.SetMaxCapacity(0)
+	b.PutU64(0)
+	assert.NoError(t, b.Err())
+	assert.Equal(t, 16, b.Count())
+
+	assert.Panics(t, func() { b.SetMaxCapacity(-1) })
+}
+
+// TestBuilder_StickyError tests that Builder writes are no-ops after a
+// failure in error mode, including ones that would otherwise grow.
+func TestBuilder_StickyError(t *testing.T) {
+	b := NewBuilder(4)
+	b.SetStrictMode(true)
+	b.PutU16(0x0102)
+	b.Rewind()
+	b.TakeU32()
+	assert.ErrorIs(t, b.Err(), ErrOutOfRange)
+
+	b.Seek(b.Count())
+	capacity := b.Capacity()
+	b.PutU64(0x0304050607080910)
+	b.PutArr8(make([]byte, 100))
+	b.Fill(0xFF, 100)
+	n, err := b.Write([]byte{0x03})
+	assert.Equal(t, 0, n)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	assert.Equal(t, []byte{0x01, 0x02}, b.Bytes())
+	assert.Equal(t, capacity, b.Capacity())
+
+	// Writes resume after ClearErr
+	b.ClearErr()
+	b.PutU16(0x0304)
+	assert.Equal(t, []byte{0x01, 0x02, 0x03, 0x04}, b.Bytes())
+}
+
+// TestBuilder_BytesBufferCompat tests the bytes.Buffer-style method set.
+func TestBuilder_BytesBufferCompat(t *testing.T) {
+	var _ io.ByteWriter = (*Builder)(nil)
+	var _ io.StringWriter = (*Builder)(nil)
+	var _ io.ByteReader = (*Builder)(nil)
+	var _ io.ReadWriter = (*Builder)(nil)
+	var _ fmt.Stringer = (*Builder)(nil)
+
+	b := NewBuilder(0)
+	assert.NoError(t, b.WriteByte('a'))
+	n, err := b.WriteString("bc")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	n, err = b.WriteRune('é')
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	_, _ = b.Write([]byte("d"))
+	assert.Equal(t, "abcéd", b.String())
+	assert.Equal(t, 6, b.Len())
+	assert.Equal(t, b.Capacity(), b.Cap())
+
+	b.Rewind()
+	c, err := b.ReadByte()
+	assert.NoError(t, err)
+	assert.Equal(t, byte('a'), c)
+
+	b.Truncate(3)
+	assert.Equal(t, "abc", b.String())
+	b.Reset()
+	assert.Equal(t, 0, b.Len())
+	assert.Equal(t, "", b.String())
+
+	// Capped builder reports write errors
+	b.SetMaxCapacity(b.Cap())
+	b.SetStrictMode(true)
+	_, err = b.WriteString(strings.Repeat("x", b.Cap()+1))
+	assert.Error(t, err)
+	assert.Error(t, b.WriteByte('x'))
+	assert.Equal(t, 0, b.Len())
+}
+
+// TestBuilder_Claim tests reserving a sub-slice for direct filling.
+func TestBuilder_Claim(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutU8(0x01)
+
+	v := b.Claim(3)
+	assert.Len(t, v, 3)
+	assert.Equal(t, 3, cap(v))
+	copy(v, "abc")
+	assert.Equal(t, 4, b.Pos())
+	assert.Equal(t, 4, b.Count())
+	b.PutU8(0x02)
+	assert.Equal(t, []byte{0x01, 'a', 'b', 'c', 0x02}, b.Bytes())
+
+	// Claiming inside existing data does not extend the count
+	b.Seek(1)
+	copy(b.Claim(2), "xy")
+	assert.Equal(t, 5, b.Count())
+	assert.Equal(t, []byte{0x01, 'x', 'y', 'c', 0x02}, b.Bytes())
+
+	assert.Len(t, b.Claim(0), 0)
+	assert.Panics(t, func() { b.Claim(-1) })
+
+	// Capped builder in error mode returns nil
+	b.SetMaxCapacity(b.Capacity())
+	b.SetStrictMode(true)
+	assert.Nil(t, b.Claim(b.Capacity()))
+	assert.Error(t, b.Err())
+}
+
+// TestBuilder_ZeroFill tests reserving zeroed space that is later overwritten.
+func TestBuilder_ZeroFill(t *testing.T) {
+	b := NewBuilder(2)
+	b.PutU8(0xAA)
+	n := b.ZeroFill(8)
+	assert.Equal(t, 8, n)
+	assert.Equal(t, 1, b.Pos())
+	assert.Equal(t, 9, b.Count())
+	assert.Equal(t, []byte{0xAA, 0, 0, 0, 0, 0, 0, 0, 0}, b.Bytes())
+
+	b.PutU8(0xBB)
+	assert.Equal(t, 9, b.Count())
+	assert.Equal(t, byte(0xBB), b.Bytes()[1])
+}
+
+// TestBuilder_GrowZeroed tests that stale bytes beyond the count are zeroed.
+func TestBuilder_GrowZeroed(t *testing.T) {
+	pooled := []byte{0xDE, 0xAD, 0xBE, 0xEF, 0xDE, 0xAD, 0xBE, 0xEF}
+	b := NewBuilderFrom(pooled[:0])
+	b.PutU8(0x01)
+
+	// No allocation needed: the stale tail is still cleared
+	b.GrowZeroed(2)
+	assert.Equal(t, 8, b.Capacity())
+	assert.Equal(t, []byte{0x01, 0, 0, 0, 0, 0, 0, 0}, pooled)
+	assert.Equal(t, 1, b.Count())
+	assert.Equal(t, []byte{0, 0, 0}, b.Claim(3))
+
+	// Growing zeroes the new capacity as well
+	b.GrowZeroed(100)
+	assert.True(t, b.Capacity() >= 104)
+	tail := b.Bytes()[b.Count():b.Capacity()]
+	assert.Equal(t, make([]byte, len(tail)), tail)
+
+	assert.Panics(t, func() { b.GrowZeroed(-1) })
+}
+
+// TestDetach tests creating an independent Builder from the readable region.
+func TestDetach(t *testing.T) {
+	b := NewBufferFrom([]byte{0x01, 0x02, 0x03, 0x04})
+	b.SetEndian(LittleEndian)
+	b.Skip(1)
+
+	d := b.Detach()
+	assert.Equal(t, []byte{0x02, 0x03, 0x04}, d.Bytes())
+	assert.Equal(t, 0, d.Pos())
+	assert.Equal(t, LittleEndian, d.GetEndian())
+	assert.False(t, d.SharesStorage(b))
+
+	// Offsets are relative to the old position, and writes grow
+	assert.Equal(t, uint16(0x0403), d.PeekAbsU16(1))
+	d.Seek(3)
+	d.PutU32(0x08070605)
+	assert.Equal(t, []byte{0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}, d.Bytes())
+	assert.Equal(t, []byte{0x01, 0x02, 0x03, 0x04}, b.Bytes())
+
+	b.Skip(3)
+	assert.Equal(t, 0, b.Detach().Count())
+}
+
+// TestClone tests deep copies of Buffer and Builder.
+func TestClone(t *testing.T) {
+	b := NewBuffer(8)
+	b.SetEndian(LittleEndian)
+	b.SetHLSwap(true)
+	b.PutU32(0x04030201)
+	b.Seek(2)
+
+	c := b.Clone()
+	assert.Equal(t, b.Bytes(), c.Bytes())
+	assert.Equal(t, b.State(), c.State())
+	assert.Equal(t, 8, c.Capacity())
+	assert.False(t, c.SharesStorage(b))
+
+	c.OverwriteU8(0, 0xFF)
+	c.PutU16(0x0605)
+	assert.Equal(t, []byte{0x02, 0x01, 0x04, 0x03}, b.Bytes())
+	assert.Equal(t, 2, b.Pos())
+	assert.Equal(t, 4, c.Pos())
+
+	// Builders keep their limit and open nested messages
+	bb := NewBuilder(0)
+	bb.SetMaxCapacity(100)
+	bb.BeginNested(1)
+	bb.PutU8(0xAA)
+	cb := bb.Clone()
+	cb.PutU8(0xBB)
+	assert.NoError(t, cb.EndNested())
+	assert.NoError(t, bb.EndNested())
+	assert.Equal(t, []byte{0x01, 0xAA}, bb.Bytes())
+	assert.Equal(t, []byte{0x02, 0xAA, 0xBB}, cb.Bytes())
+	assert.Panics(t, func() { cb.Grow(200) })
+}
+
+// TestAsBuilderAsBuffer tests converting between Buffer and Builder.
+func TestAsBuilderAsBuffer(t *testing.T) {
+	b := NewBuffer(4)
+	b.SetEndian(LittleEndian)
+	b.PutU8(0x01)
+
+	bb := b.AsBuilder()
+	assert.Equal(t, 1, bb.Pos())
+	assert.Equal(t, LittleEndian, bb.GetEndian())
+	bb.PutU16(0x0302)
+	assert.True(t, bb.SharesStorage(b))
+	assert.Equal(t, byte(0x02), b.Bytes()[:3][1], "writes within capacity are shared")
+	assert.Equal(t, 1, b.Pos(), "positions are independent")
+
+	bb.PutU32(0x07060504) // grows and detaches
+	assert.False(t, bb.SharesStorage(b))
+	assert.Equal(t, []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07}, bb.Bytes())
+
+	// AsBuffer is the embedded Buffer itself
+	view := bb.AsBuffer()
+	assert.Same(t, &bb.Buffer, view)
+	view.Rewind()
+	assert.Equal(t, 0, bb.Pos())
+	full := NewBuilder(1)
+	full.PutU8(1)
+	assert.Panics(t, func() { full.AsBuffer().PutU8(2) })
+}
+
+// TestBuilder_Recycle tests resetting a Builder for pooled reuse.
+func TestBuilder_Recycle(t *testing.T) {
+	b := NewBuilder(16)
+	b.SetEndian(LittleEndian)
+	b.PutU32(1)
+	b.Recycle(64)
+	assert.Equal(t, 0, b.Count())
+	assert.Equal(t, 0, b.Pos())
+	assert.Equal(t, 16, b.Capacity(), "small arrays are retained")
+	assert.Equal(t, LittleEndian, b.GetEndian())
+
+	b.PutArr8(make([]byte, 1000))
+	b.Recycle(64)
+	assert.Equal(t, 0, b.Capacity(), "oversized arrays are dropped")
+	b.PutU16(0x0201)
+	assert.Equal(t, []byte{0x01, 0x02}, b.Bytes())
+
+	// Errors and nested frames are discarded
+	b.SetStrictMode(true)
+	b.SetMaxCapacity(64)
+	b.PutArr8(make([]byte, 65))
+	assert.Error(t, b.Err())
+	b.Recycle(64)
+	assert.NoError(t, b.Err())
+	b.PutU8(1)
+	assert.Equal(t, 1, b.Count())
+
+	assert.Panics(t, func() { b.Recycle(-1) })
+}
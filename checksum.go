@@ -0,0 +1,288 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"fmt"
+	"hash"
+	"hash/adler32"
+)
+
+// fletcher16 computes the Fletcher-16 checksum of p.
+func fletcher16(p []byte) uint16 {
+	var s1, s2 uint32
+	for len(p) > 0 {
+		// 5802 bytes keep both sums below 2^32 before reduction
+		n := len(p)
+		if n > 5802 {
+			n = 5802
+		}
+		for _, c := range p[:n] {
+			s1 += uint32(c)
+			s2 += s1
+		}
+		s1 %= 255
+		s2 %= 255
+		p = p[n:]
+	}
+	return uint16(s2<<8 | s1)
+}
+
+// fletcher32 computes the Fletcher-32 checksum of p, taken as little-endian
+// 16-bit words with an odd trailing byte zero-padded.
+func fletcher32(p []byte) uint32 {
+	var s1, s2 uint64
+	for len(p) > 0 {
+		n := len(p)
+		if n > 720 {
+			n = 720
+		}
+		for i := 0; i < n; i += 2 {
+			w := uint64(p[i])
+			if i+1 < n {
+				w |= uint64(p[i+1]) << 8
+			}
+			s1 += w
+			s2 += s1
+		}
+		s1 %= 65535
+		s2 %= 65535
+		p = p[n:]
+	}
+	return uint32(s2<<16 | s1)
+}
+
+// checkChecksumStart checks if start is within [0, pos].
+func (b *Buffer) checkChecksumStart(method string, start int) bool {
+	if b.err != nil {
+		return false
+	}
+	if start < 0 || start > b.pos {
+		return b.fail(fmt.Errorf("mbuff.Buffer.%s: start %d out of bounds [0, %d]: %w", method, start, b.pos, ErrOutOfRange))
+	}
+	return true
+}
+
+// checkChecksumRange checks if [start, end) followed by an n-byte checksum
+// is within the count.
+func (b *Buffer) checkChecksumRange(method string, start, end, n int) bool {
+	if b.err != nil {
+		return false
+	}
+	if start < 0 || start > end || end+n > len(b.data) {
+		return b.fail(fmt.Errorf("mbuff.Buffer.%s: range [%d, %d) + %d exceeds count %d: %w", method, start, end, n, len(b.data), ErrOutOfRange))
+	}
+	return true
+}
+
+// AppendAdler32 writes the Adler-32 checksum of [start, pos) as a uint32 at
+// the current position and advances the position.
+func (b *Buffer) AppendAdler32(start int) {
+	if !b.checkChecksumStart("AppendAdler32", start) {
+		return
+	}
+	b.PutU32(adler32.Checksum(b.data[start:b.pos]))
+}
+
+// VerifyAdler32 reports whether the uint32 stored at end matches the Adler-32
+// checksum of [start, end). The position is not changed.
+func (b *Buffer) VerifyAdler32(start, end int) bool {
+	if !b.checkChecksumRange("VerifyAdler32", start, end, 4) {
+		return false
+	}
+	stored := b.HLSwap32(b.order.Uint32(b.data[end:]))
+	return stored == adler32.Checksum(b.data[start:end])
+}
+
+// AppendFletcher16 writes the Fletcher-16 checksum of [start, pos) as a
+// uint16 at the current position and advances the position.
+func (b *Buffer) AppendFletcher16(start int) {
+	if !b.checkChecksumStart("AppendFletcher16", start) {
+		return
+	}
+	b.PutU16(fletcher16(b.data[start:b.pos]))
+}
+
+// VerifyFletcher16 reports whether the uint16 stored at end matches the
+// Fletcher-16 checksum of [start, end). The position is not changed.
+func (b *Buffer) VerifyFletcher16(start, end int) bool {
+	if !b.checkChecksumRange("VerifyFletcher16", start, end, 2) {
+		return false
+	}
+	return b.order.Uint16(b.data[end:]) == fletcher16(b.data[start:end])
+}
+
+// AppendFletcher32 writes the Fletcher-32 checksum of [start, pos) as a
+// uint32 at the current position and advances the position. The data is
+// summed as little-endian 16-bit words, zero-padding an odd trailing byte.
+func (b *Buffer) AppendFletcher32(start int) {
+	if !b.checkChecksumStart("AppendFletcher32", start) {
+		return
+	}
+	b.PutU32(fletcher32(b.data[start:b.pos]))
+}
+
+// VerifyFletcher32 reports whether the uint32 stored at end matches the
+// Fletcher-32 checksum of [start, end). The position is not changed.
+func (b *Buffer) VerifyFletcher32(start, end int) bool {
+	if !b.checkChecksumRange("VerifyFletcher32", start, end, 4) {
+		return false
+	}
+	stored := b.HLSwap32(b.order.Uint32(b.data[end:]))
+	return stored == fletcher32(b.data[start:end])
+}
+
+// sum8 returns the sum of p modulo 256.
+func sum8(p []byte) uint8 {
+	var s uint8
+	for _, c := range p {
+		s += c
+	}
+	return s
+}
+
+// sum16 returns the sum of p modulo 65536.
+func sum16(p []byte) uint16 {
+	var s uint16
+	for _, c := range p {
+		s += uint16(c)
+	}
+	return s
+}
+
+// SumChecksum8 returns the sum of the bytes in [start, end) modulo 256.
+// The position is not changed.
+func (b *Buffer) SumChecksum8(start, end int) uint8 {
+	if !b.checkChecksumRange("SumChecksum8", start, end, 0) {
+		return 0
+	}
+	return sum8(b.data[start:end])
+}
+
+// SumChecksum16 returns the sum of the bytes in [start, end) modulo 65536.
+// The position is not changed.
+func (b *Buffer) SumChecksum16(start, end int) uint16 {
+	if !b.checkChecksumRange("SumChecksum16", start, end, 0) {
+		return 0
+	}
+	return sum16(b.data[start:end])
+}
+
+// AppendSumChecksum8 writes the 8-bit additive checksum of [start, pos) at
+// the current position and advances the position. If negate is set, the
+// two's complement of the sum is written instead, so that the covered bytes
+// plus the checksum sum to zero.
+func (b *Buffer) AppendSumChecksum8(start int, negate bool) {
+	if !b.checkChecksumStart("AppendSumChecksum8", start) {
+		return
+	}
+	s := sum8(b.data[start:b.pos])
+	if negate {
+		s = -s
+	}
+	b.PutU8(s)
+}
+
+// AppendSumChecksum16 writes the 16-bit additive checksum of [start, pos) as
+// a uint16 at the current position and advances the position. If negate is
+// set, the two's complement of the sum is written instead.
+func (b *Buffer) AppendSumChecksum16(start int, negate bool) {
+	if !b.checkChecksumStart("AppendSumChecksum16", start) {
+		return
+	}
+	s := sum16(b.data[start:b.pos])
+	if negate {
+		s = -s
+	}
+	b.PutU16(s)
+}
+
+// EnableRunningChecksum starts feeding every byte consumed from now on into
+// h, e.g. crc32.NewIEEE() or adler32.New(), so a trailing checksum can be
+// verified after decoding without a second pass over the data. h is reset
+// first. A byte counts as consumed once a Take, Read or other decoding method
+// moves the position past it. Jumps with Skip, Seek, Rewind or a restored
+// mark consume nothing: bytes jumped over forward are not counted, moving
+// backwards does not uncount bytes, and bytes consumed again are counted
+// again. The hash is updated lazily, when the checksum is queried, the
+// position jumps or data is modified in place, so consuming methods have no
+// overhead and bytes are hashed with the values they were consumed with.
+func (b *Buffer) EnableRunningChecksum(h hash.Hash32) {
+	h.Reset()
+	b.csHash = h
+	b.csPos = b.pos
+}
+
+// DisableRunningChecksum stops the running checksum.
+func (b *Buffer) DisableRunningChecksum() { b.csHash = nil }
+
+// RunningChecksum returns the checksum of the bytes consumed since
+// EnableRunningChecksum or ResetRunningChecksum, or 0 if it is disabled.
+func (b *Buffer) RunningChecksum() uint32 {
+	if b.csHash == nil {
+		return 0
+	}
+	b.syncChecksum()
+	return b.csHash.Sum32()
+}
+
+// ResetRunningChecksum restarts the running checksum at the current position.
+func (b *Buffer) ResetRunningChecksum() {
+	if b.csHash == nil {
+		return
+	}
+	b.csHash.Reset()
+	b.csPos = b.pos
+}
+
+// syncChecksum feeds the bytes consumed since the last update into the
+// running checksum.
+func (b *Buffer) syncChecksum() {
+	if b.csHash == nil || b.pos <= b.csPos {
+		return
+	}
+	b.csHash.Write(b.data[b.csPos:b.pos])
+	b.csPos = b.pos
+}
+
+// setPos jumps to pos without consuming anything, keeping the running
+// checksum in sync: the bytes consumed so far are accounted for first, and
+// counting resumes at pos.
+func (b *Buffer) setPos(pos int) {
+	if b.csHash != nil && pos != b.pos {
+		b.syncChecksum()
+		b.csPos = pos
+	}
+	b.pos = pos
+}
+
+// PutProtectedLength writes n as a uint32 followed by a one-byte check of
+// those 4 bytes, the one's complement of their sum, and advances the
+// position. The complement keeps an all-zero header from validating.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutProtectedLength(n uint32) {
+	if !b.checkWritable("PutProtectedLength", b.pos+5) {
+		return
+	}
+	b.PutU32(n)
+	b.PutU8(^sum8(b.data[b.pos-4 : b.pos]))
+}
+
+// TakeProtectedLength reads a length written by PutProtectedLength, then
+// advances the position. Returns an error wrapping ErrChecksum, without
+// advancing, if the check byte does not match, or the recorded error if fewer
+// than 5 bytes are readable in error mode.
+func (b *Buffer) TakeProtectedLength() (uint32, error) {
+	if !b.checkReadable(5) {
+		return 0, b.err
+	}
+	if want, got := ^sum8(b.data[b.pos:b.pos+4]), b.data[b.pos+4]; got != want {
+		return 0, fmt.Errorf("mbuff.Buffer.TakeProtectedLength: length at pos %d has check 0x%02X, want 0x%02X: %w", b.pos, got, want, ErrChecksum)
+	}
+	n := b.TakeU32()
+	b.pos++
+	return n, nil
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"bytes"
+	"hash/crc32"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestChecksumVectors tests the checksums against known vectors.
+func TestChecksumVectors(t *testing.T) {
+	assert.Equal(t, uint16(0xC8F0), fletcher16([]byte("abcde")))
+	assert.Equal(t, uint16(0x2057), fletcher16([]byte("abcdef")))
+	assert.Equal(t, uint16(0x0627), fletcher16([]byte("abcdefgh")))
+	assert.Equal(t, uint32(0xF04FC729), fletcher32([]byte("abcde")))
+	assert.Equal(t, uint32(0x56502D2A), fletcher32([]byte("abcdef")))
+	assert.Equal(t, uint32(0xEBE19591), fletcher32([]byte("abcdefgh")))
+
+	// Long inputs exercise the deferred reduction
+	long := bytes.Repeat([]byte{0xFF}, 100000)
+	var s1, s2 uint32
+	for _, c := range long {
+		s1 = (s1 + uint32(c)) % 255
+		s2 = (s2 + s1) % 255
+	}
+	assert.Equal(t, uint16(s2<<8|s1), fletcher16(long))
+}
+
+// TestAdler32 tests appending and verifying Adler-32 checksums.
+func TestAdler32(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutStr("Wikipedia")
+	b.AppendAdler32(0)
+	assert.Equal(t, uint32(0x11E60398), b.PeekAbsU32(9))
+	assert.True(t, b.VerifyAdler32(0, 9))
+
+	b.OverwriteU8(0, 'w')
+	assert.False(t, b.VerifyAdler32(0, 9))
+
+	// Sub-range and buffer byte order
+	b.Clear()
+	b.SetEndian(LittleEndian)
+	b.PutU8(0xFF)
+	b.PutStr("Wikipedia")
+	b.AppendAdler32(1)
+	assert.Equal(t, []byte{0x98, 0x03, 0xE6, 0x11}, b.Bytes()[10:])
+	assert.True(t, b.VerifyAdler32(1, 10))
+
+	assert.Panics(t, func() { b.AppendAdler32(b.Pos() + 1) })
+	assert.Panics(t, func() { b.VerifyAdler32(0, 11) })
+}
+
+// TestFletcher tests appending and verifying Fletcher checksums.
+func TestFletcher(t *testing.T) {
+	b := NewBuffer(32)
+	b.PutStr("abcde")
+	b.AppendFletcher16(0)
+	assert.Equal(t, uint16(0xC8F0), b.PeekAbsU16(5))
+	assert.True(t, b.VerifyFletcher16(0, 5))
+
+	start := b.Pos()
+	b.PutStr("abcde")
+	b.AppendFletcher32(start)
+	assert.Equal(t, uint32(0xF04FC729), b.PeekAbsU32(start+5))
+	assert.True(t, b.VerifyFletcher32(start, start+5))
+	assert.False(t, b.VerifyFletcher32(0, 5))
+
+	b.SetStrictMode(true)
+	assert.False(t, b.VerifyFletcher16(3, 2))
+	assert.Error(t, b.Err())
+
+	bb := NewBuilder(0)
+	bb.PutStr("abcdef")
+	bb.AppendFletcher16(0)
+	bb.AppendFletcher32(0)
+	assert.True(t, bb.VerifyFletcher16(0, 6))
+	assert.True(t, bb.VerifyFletcher32(0, 8))
+}
+
+// TestSumChecksum tests additive checksums and their two's complement.
+func TestSumChecksum(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutStr("abcde") // 495 = 0x01EF
+	assert.Equal(t, uint8(0xEF), b.SumChecksum8(0, 5))
+	assert.Equal(t, uint16(0x01EF), b.SumChecksum16(0, 5))
+	assert.Equal(t, uint8(0x62), b.SumChecksum8(1, 2))
+	assert.Equal(t, uint16(0), b.SumChecksum16(3, 3))
+
+	b.AppendSumChecksum8(0, false)
+	b.AppendSumChecksum8(0, true) // covers the previous checksum as well
+	assert.Equal(t, uint8(0xEF), b.PeekAbsU8(5))
+	assert.Equal(t, uint8(0x22), b.PeekAbsU8(6))
+	assert.Equal(t, uint8(0), b.SumChecksum8(0, 7))
+
+	start := b.Pos()
+	b.PutStr("abcde")
+	b.AppendSumChecksum16(start, false)
+	assert.Equal(t, uint16(0x01EF), b.PeekAbsU16(start+5))
+	b.AppendSumChecksum16(start, true)
+	assert.Equal(t, uint16(0xFD21), b.PeekAbsU16(start+7)) // -(495 + 0x01 + 0xEF)
+
+	assert.Panics(t, func() { b.SumChecksum8(0, b.Count()+1) })
+	assert.Panics(t, func() { b.AppendSumChecksum8(b.Pos()+1, false) })
+}
+
+// TestRunningChecksum tests checksumming consumed bytes while decoding.
+func TestRunningChecksum(t *testing.T) {
+	body := []byte("123456789")
+	b := NewBuilder(0)
+	b.PutU8(0x00) // header, not covered
+	b.PutArr8(body)
+	b.PutU32(crc32.ChecksumIEEE(body))
+	b.Rewind()
+
+	assert.Equal(t, uint32(0), b.RunningChecksum())
+	b.TakeU8()
+	b.EnableRunningChecksum(crc32.NewIEEE())
+	b.TakeU16()
+	b.TakeStr(3)
+	assert.Equal(t, crc32.ChecksumIEEE(body[:5]), b.RunningChecksum())
+	b.TakeU8()
+	p := make([]byte, 3)
+	_, _ = b.Read(p)
+	sum := b.RunningChecksum()
+	assert.Equal(t, uint32(0xCBF43926), sum)
+	assert.Equal(t, sum, b.TakeU32())
+
+	// Reset restarts at the current position
+	b.Seek(1)
+	b.ResetRunningChecksum()
+	b.TakeU16()
+	assert.Equal(t, crc32.ChecksumIEEE(body[:2]), b.RunningChecksum())
+
+	// Bytes consumed before a jump are kept, and re-read bytes count again
+	b.TakeU8()
+	b.Seek(1)
+	b.TakeU8()
+	assert.Equal(t, crc32.ChecksumIEEE([]byte("1231")), b.RunningChecksum())
+
+	// Compact keeps the bytes consumed before it
+	b.ResetRunningChecksum()
+	b.TakeU16()
+	b.Compact()
+	b.TakeU8()
+	assert.Equal(t, crc32.ChecksumIEEE([]byte("234")), b.RunningChecksum())
+
+	b.DisableRunningChecksum()
+	assert.Equal(t, uint32(0), b.RunningChecksum())
+}
+
+// TestRunningChecksum_Jumps tests that jumps and in-place modification do
+// not change what counts as consumed.
+func TestRunningChecksum_Jumps(t *testing.T) {
+	b := NewBufferFrom([]byte("123456789"))
+	b.EnableRunningChecksum(crc32.NewIEEE())
+
+	// Skipped and forward-seeked bytes are not counted
+	b.TakeU8()
+	b.Skip(2)
+	b.TakeU8()
+	_, _ = b.SkipOrErr(1)
+	b.Seek(7)
+	b.TakeU8()
+	assert.Equal(t, crc32.ChecksumIEEE([]byte("148")), b.RunningChecksum())
+
+	// Consumed bytes keep the values they were read with
+	b.ResetRunningChecksum()
+	b.Seek(0)
+	b.TakeU16()
+	b.OverwriteU8(0, 'x')
+	b.XORRange(1, 2, []byte{0xFF})
+	b.PatchU8(2, 'y')
+	b.TakeU8()
+	assert.Equal(t, crc32.ChecksumIEEE([]byte("12y")), b.RunningChecksum())
+
+	// A failed dictionary read rolls back like a jump, so the bytes it
+	// consumed count again when re-read
+	b = NewBufferFrom([]byte{'a', dictRef, 5})
+	b.SetStrictMode(true)
+	b.EnableRunningChecksum(crc32.NewIEEE())
+	b.TakeU8()
+	NewDictDecoder(b, 4).ReadBlob()
+	assert.Error(t, b.Err())
+	assert.Equal(t, 1, b.Pos())
+	b.ClearErr()
+	b.TakeU16()
+	assert.Equal(t, crc32.ChecksumIEEE([]byte{'a', dictRef, 5, dictRef, 5}), b.RunningChecksum())
+}
+
+// TestProtectedLength tests length fields guarded by a check byte.
+func TestProtectedLength(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutProtectedLength(0x01020304)
+	b.PutProtectedLength(0)
+	assert.Equal(t, []byte{1, 2, 3, 4, 0xF5, 0, 0, 0, 0, 0xFF}, b.Bytes())
+
+	b.Rewind()
+	n, err := b.TakeProtectedLength()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0x01020304), n)
+	n, err = b.TakeProtectedLength()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), n)
+
+	// Corruption of the length or the check byte is detected
+	for _, i := range []int{0, 3, 4} {
+		c := NewBufferFrom(bytes.Clone(b.Bytes()[:5]))
+		c.data[i] ^= 0x10
+		_, err = c.TakeProtectedLength()
+		assert.ErrorIs(t, err, ErrChecksum)
+		assert.Equal(t, 0, c.Pos())
+	}
+	_, err = NewBufferFrom(make([]byte, 5)).TakeProtectedLength()
+	assert.ErrorIs(t, err, ErrChecksum)
+
+	r := NewBufferFrom([]byte{0, 0, 0, 0})
+	r.SetStrictMode(true)
+	_, err = r.TakeProtectedLength()
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	assert.Panics(t, func() { NewBuffer(4).PutProtectedLength(1) })
+}
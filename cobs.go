@@ -0,0 +1,146 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// cobsMaxLen returns an upper bound on the length of an encoded frame of n
+// bytes, including the delimiter.
+func cobsMaxLen(n int) int { return n + n/254 + 2 }
+
+// cobsEncode writes the COBS encoding of src to dst, without a delimiter,
+// and returns its length. If reduced is set, the COBS/R variant is used: when
+// the final data byte is not smaller than the final length code, it replaces
+// the code and is dropped from the end, saving one byte.
+// dst may be nil to only compute the length; otherwise it must have room for
+// one byte more than the returned length.
+func cobsEncode(dst, src []byte, reduced bool) int {
+	codePos, n := 0, 1
+	code := byte(1)
+	var last byte
+	for i, c := range src {
+		last = c
+		if c == 0 {
+			if dst != nil {
+				dst[codePos] = code
+			}
+			codePos, code = n, 1
+			n++
+			continue
+		}
+		if dst != nil {
+			dst[n] = c
+		}
+		n++
+		code++
+		// A full block of 254 data bytes ends without an implied zero; a new
+		// block starts only if more data follows.
+		if code == 0xFF && i < len(src)-1 {
+			if dst != nil {
+				dst[codePos] = code
+			}
+			codePos, code = n, 1
+			n++
+		}
+	}
+	if reduced && last >= code {
+		code = last
+		n--
+	}
+	if dst != nil {
+		dst[codePos] = code
+	}
+	return n
+}
+
+// cobsDecode decodes a COBS or, if reduced is set, COBS/R frame without its
+// delimiter. src must not contain zero bytes.
+func cobsDecode(src []byte, reduced bool) ([]byte, error) {
+	if len(src) == 0 {
+		return nil, errors.New("empty frame")
+	}
+	dst := make([]byte, 0, len(src))
+	for i := 0; i < len(src); {
+		code := int(src[i])
+		i++
+		if code-1 > len(src)-i {
+			if !reduced {
+				return nil, fmt.Errorf("block of %d bytes at offset %d exceeds frame", code-1, i)
+			}
+			// COBS/R: the length code is the final data byte
+			dst = append(dst, src[i:]...)
+			return append(dst, byte(code)), nil
+		}
+		dst = append(dst, src[i:i+code-1]...)
+		i += code - 1
+		if code != 0xFF && i < len(src) {
+			dst = append(dst, 0)
+		}
+	}
+	return dst, nil
+}
+
+// encodeCOBS writes the encoded frame of src followed by a zero delimiter.
+func (b *Buffer) encodeCOBS(method string, src []byte, reduced bool) {
+	n := cobsEncode(nil, src, reduced)
+	required := b.pos + n + 1
+	if !b.checkWritable(method, required) {
+		return
+	}
+
+	cobsEncode(b.data[b.pos:required], src, reduced)
+	b.data[b.pos+n] = 0
+	b.pos = required
+}
+
+// decodeCOBS reads a frame up to and including its zero delimiter.
+func (b *Buffer) decodeCOBS(method string, reduced bool) ([]byte, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	end := bytes.IndexByte(b.data[b.pos:], 0)
+	if end < 0 {
+		return nil, fmt.Errorf("mbuff.Buffer.%s: unterminated frame at pos %d", method, b.pos)
+	}
+	v, err := cobsDecode(b.data[b.pos:b.pos+end], reduced)
+	if err != nil {
+		return nil, fmt.Errorf("mbuff.Buffer.%s: malformed frame at pos %d: %w", method, b.pos, err)
+	}
+	b.pos += end + 1
+	return v, nil
+}
+
+// EncodeCOBS writes src with Consistent Overhead Byte Stuffing at the current
+// position, followed by a zero delimiter, and advances the position. The
+// encoded frame contains no zero bytes, so the delimiter marks its end.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) EncodeCOBS(src []byte) {
+	b.encodeCOBS("EncodeCOBS", src, false)
+}
+
+// DecodeCOBS reads a frame written by EncodeCOBS up to and including its zero
+// delimiter, and returns the decoded bytes in a new slice. Returns an error,
+// without advancing, if no delimiter follows or the frame is malformed.
+func (b *Buffer) DecodeCOBS() ([]byte, error) {
+	return b.decodeCOBS("DecodeCOBS", false)
+}
+
+// EncodeCOBSR is like EncodeCOBS but uses the reduced COBS/R variant, which
+// saves the final byte when it is not smaller than the final length code.
+// The output differs from plain COBS only in the final block.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) EncodeCOBSR(src []byte) {
+	b.encodeCOBS("EncodeCOBSR", src, true)
+}
+
+// DecodeCOBSR is like DecodeCOBS for frames written by EncodeCOBSR.
+func (b *Buffer) DecodeCOBSR() ([]byte, error) {
+	return b.decodeCOBS("DecodeCOBSR", true)
+}
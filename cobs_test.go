@@ -0,0 +1,117 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// seq returns the bytes from..to inclusive.
+func seq(from, to int) []byte {
+	v := make([]byte, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		v = append(v, byte(i))
+	}
+	return v
+}
+
+// cat concatenates byte slices.
+func cat(parts ...[]byte) []byte {
+	var v []byte
+	for _, p := range parts {
+		v = append(v, p...)
+	}
+	return v
+}
+
+// cobsVectors are reference vectors as {decoded, COBS, COBS/R}, without delimiter.
+var cobsVectors = [][3][]byte{
+	{{}, {0x01}, {0x01}},
+	{{0x00}, {0x01, 0x01}, {0x01, 0x01}},
+	{{0x00, 0x00}, {0x01, 0x01, 0x01}, {0x01, 0x01, 0x01}},
+	{{0x11, 0x22, 0x00, 0x33}, {0x03, 0x11, 0x22, 0x02, 0x33}, {0x03, 0x11, 0x22, 0x33}},
+	{{0x11, 0x22, 0x33, 0x44}, {0x05, 0x11, 0x22, 0x33, 0x44}, {0x44, 0x11, 0x22, 0x33}},
+	{{0x11, 0x00, 0x00, 0x00}, {0x02, 0x11, 0x01, 0x01, 0x01}, {0x02, 0x11, 0x01, 0x01, 0x01}},
+	{{0x01, 0x02, 0x03}, {0x04, 0x01, 0x02, 0x03}, {0x04, 0x01, 0x02, 0x03}},
+	{{0x02}, {0x02, 0x02}, {0x02}},
+	{seq(1, 254), cat([]byte{0xFF}, seq(1, 254)), cat([]byte{0xFF}, seq(1, 254))},
+	{seq(0, 254), cat([]byte{0x01, 0xFF}, seq(1, 254)), cat([]byte{0x01, 0xFF}, seq(1, 254))},
+	{seq(1, 255), cat([]byte{0xFF}, seq(1, 254), []byte{0x02, 0xFF}), cat([]byte{0xFF}, seq(1, 254), []byte{0xFF})},
+	{cat(seq(2, 255), []byte{0x00}), cat([]byte{0xFF}, seq(2, 255), []byte{0x01, 0x01}), cat([]byte{0xFF}, seq(2, 255), []byte{0x01, 0x01})},
+}
+
+// TestCOBS tests plain COBS against reference vectors.
+func TestCOBS(t *testing.T) {
+	for _, vec := range cobsVectors {
+		b := NewBuilder(0)
+		b.EncodeCOBS(vec[0])
+		assert.Equal(t, cat(vec[1], []byte{0x00}), b.Bytes())
+
+		b.Rewind()
+		v, err := b.DecodeCOBS()
+		assert.NoError(t, err)
+		assert.Equal(t, vec[0], v)
+		assert.Equal(t, 0, b.Readable())
+	}
+}
+
+// TestCOBSR tests COBS/R against reference vectors.
+func TestCOBSR(t *testing.T) {
+	for _, vec := range cobsVectors {
+		b := NewBuilder(0)
+		b.EncodeCOBSR(vec[0])
+		assert.Equal(t, cat(vec[2], []byte{0x00}), b.Bytes())
+
+		b.Rewind()
+		v, err := b.DecodeCOBSR()
+		assert.NoError(t, err)
+		assert.Equal(t, vec[0], v)
+		assert.Equal(t, 0, b.Readable())
+	}
+}
+
+// TestCOBS_Frames tests decoding consecutive frames and malformed input.
+func TestCOBS_Frames(t *testing.T) {
+	b := NewBuilder(0)
+	b.EncodeCOBS([]byte{0x11, 0x22, 0x33, 0x44})
+	b.EncodeCOBSR([]byte{0x11, 0x22, 0x33, 0x44})
+	b.Rewind()
+	v, err := b.DecodeCOBS()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x11, 0x22, 0x33, 0x44}, v)
+	v, err = b.DecodeCOBSR()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x11, 0x22, 0x33, 0x44}, v)
+
+	// A COBS/R frame is not valid plain COBS
+	r := NewBufferFrom([]byte{0x44, 0x11, 0x22, 0x33, 0x00})
+	_, err = r.DecodeCOBS()
+	assert.ErrorContains(t, err, "malformed frame")
+	assert.Equal(t, 0, r.Pos())
+
+	// Missing delimiter
+	r = NewBufferFrom([]byte{0x02, 0x11})
+	_, err = r.DecodeCOBS()
+	assert.ErrorContains(t, err, "unterminated frame")
+	assert.Equal(t, 0, r.Pos())
+
+	// Empty frame
+	r = NewBufferFrom([]byte{0x00})
+	_, err = r.DecodeCOBSR()
+	assert.Error(t, err)
+	assert.Equal(t, 0, r.Pos())
+
+	// Overflow panics
+	fixed := NewBuffer(4)
+	assert.Panics(t, func() { fixed.EncodeCOBS([]byte{1, 2, 3}) })
+
+	// The byte saved by COBS/R can make a frame fit
+	fixed = NewBuffer(4)
+	fixed.EncodeCOBSR([]byte{1, 2, 4})
+	assert.Equal(t, []byte{0x04, 0x01, 0x02, 0x00}, fixed.Bytes())
+}
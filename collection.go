@@ -0,0 +1,83 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"fmt"
+)
+
+// checkCount validates the element count for a collection of elemLen-byte
+// elements and returns the encoded length including the u32 count prefix.
+func (b *Buffer) checkCount(method string, n int, elemLen int) (int, bool) {
+	if uint64(n) > 0xFFFFFFFF {
+		return 0, b.fail(fmt.Errorf("mbuff.Buffer.%s: count %d exceeds u32 prefix", method, n))
+	}
+	return 4 + n*elemLen, true
+}
+
+// PutSliceU32 writes v as a u32 element count followed by the elements,
+// then advances the position.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutSliceU32(v []uint32) {
+	byteLen, ok := b.checkCount("PutSliceU32", len(v), 4)
+	if !ok || !b.checkWritable("PutSliceU32", b.pos+byteLen) {
+		return
+	}
+	b.PutU32(uint32(len(v)))
+	b.PutArr32(v)
+}
+
+// TakeSliceU32 reads a slice written by PutSliceU32 into a new slice, then
+// advances the position. The position is left unchanged if the data is incomplete.
+func (b *Buffer) TakeSliceU32() []uint32 {
+	if !b.checkReadable(4) {
+		return nil
+	}
+	n := int(b.HLSwap32(b.order.Uint32(b.data[b.pos:])))
+	if !b.checkReadable(4 + n<<2) {
+		return nil
+	}
+	b.pos += 4
+	v := make([]uint32, n)
+	b.TakeArr32(v)
+	return v
+}
+
+// PutMapU32U32 writes m as a u32 entry count followed by key/value pairs,
+// then advances the position. Entries are written in Go's map iteration
+// order, so the encoding is not deterministic, but it always round-trips.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutMapU32U32(m map[uint32]uint32) {
+	byteLen, ok := b.checkCount("PutMapU32U32", len(m), 8)
+	if !ok || !b.checkWritable("PutMapU32U32", b.pos+byteLen) {
+		return
+	}
+	b.PutU32(uint32(len(m)))
+	for k, v := range m {
+		b.PutU32(k)
+		b.PutU32(v)
+	}
+}
+
+// TakeMapU32U32 reads a map written by PutMapU32U32 into a new map, then
+// advances the position. Duplicate keys keep the last value. The position is
+// left unchanged if the data is incomplete.
+func (b *Buffer) TakeMapU32U32() map[uint32]uint32 {
+	if !b.checkReadable(4) {
+		return nil
+	}
+	n := int(b.HLSwap32(b.order.Uint32(b.data[b.pos:])))
+	if !b.checkReadable(4 + n<<3) {
+		return nil
+	}
+	b.pos += 4
+	m := make(map[uint32]uint32, n)
+	for i := 0; i < n; i++ {
+		k := b.TakeU32()
+		m[k] = b.TakeU32()
+	}
+	return m
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSliceU32 tests count-prefixed slice round trips.
+func TestSliceU32(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutSliceU32([]uint32{0x11223344, 0x55667788})
+	b.PutSliceU32(nil)
+	assert.Equal(t, []byte{0, 0, 0, 2, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0, 0, 0, 0}, b.Bytes())
+
+	b.Rewind()
+	assert.Equal(t, []uint32{0x11223344, 0x55667788}, b.TakeSliceU32())
+	assert.Equal(t, []uint32{}, b.TakeSliceU32())
+	assert.Equal(t, 0, b.Readable())
+
+	// Truncated data leaves pos unchanged
+	r := NewBufferFrom([]byte{0, 0, 0, 2, 0, 0, 0, 1})
+	assert.Panics(t, func() { r.TakeSliceU32() })
+	assert.Equal(t, 0, r.Pos())
+
+	// Overflow writes nothing
+	w := NewBuffer(8)
+	assert.Panics(t, func() { w.PutSliceU32([]uint32{1, 2}) })
+	assert.Equal(t, 0, w.Count())
+}
+
+// TestMapU32U32 tests count-prefixed map round trips.
+func TestMapU32U32(t *testing.T) {
+	m := map[uint32]uint32{1: 10, 2: 20, 3: 30}
+	b := NewBuilder(0)
+	b.PutMapU32U32(m)
+	b.PutMapU32U32(nil)
+	assert.Equal(t, 4+3*8+4, b.Count())
+
+	b.Rewind()
+	assert.Equal(t, m, b.TakeMapU32U32())
+	assert.Equal(t, map[uint32]uint32{}, b.TakeMapU32U32())
+
+	// Truncated data leaves pos unchanged
+	r := NewBufferFrom([]byte{0, 0, 0, 1, 0, 0, 0, 1})
+	r.SetStrictMode(true)
+	assert.Nil(t, r.TakeMapU32U32())
+	assert.Error(t, r.Err())
+	assert.Equal(t, 0, r.Pos())
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"fmt"
+	"math"
+)
+
+// DefaultCoordScale is the scale used by PutCoord and TakeCoord: degrees are
+// stored in units of 1e-7 degree, as in GPS receivers and MAVLink.
+const DefaultCoordScale = 1e7
+
+// checkCoordScale panics unless scale is positive and finite.
+func checkCoordScale(method string, scale float64) {
+	if !(scale > 0) || math.IsInf(scale, 1) {
+		panic(fmt.Sprintf("mbuff.%s: invalid scale %v", method, scale))
+	}
+}
+
+// toCoord scales degrees to an int32, rounding half to even and saturating to
+// the int32 range. NaN is encoded as 0.
+func toCoord(degrees, scale float64) int32 {
+	if math.IsNaN(degrees) {
+		return 0
+	}
+	r := math.RoundToEven(degrees * scale)
+	if r >= math.MaxInt32 {
+		return math.MaxInt32
+	}
+	if r <= math.MinInt32 {
+		return math.MinInt32
+	}
+	return int32(r)
+}
+
+// PutCoord writes degrees as round(degrees * DefaultCoordScale) in a
+// two's complement int32, then advances the position.
+// Panics if the write would exceed the buffer's capacity.
+func (b *Buffer) PutCoord(degrees float64) {
+	b.PutI32(toCoord(degrees, DefaultCoordScale))
+}
+
+// TakeCoord reads a coordinate written by PutCoord and returns it in degrees,
+// then advances the position.
+func (b *Buffer) TakeCoord() float64 {
+	return float64(b.TakeI32()) / DefaultCoordScale
+}
+
+// PutCoordScaled writes degrees as round(degrees * scale) in a two's
+// complement int32, then advances the position. Halves round to even and
+// values beyond the int32 range saturate.
+// Panics if scale is not positive and finite or the write would exceed the
+// buffer's capacity.
+func (b *Buffer) PutCoordScaled(degrees, scale float64) {
+	checkCoordScale("Buffer.PutCoordScaled", scale)
+	b.PutI32(toCoord(degrees, scale))
+}
+
+// TakeCoordScaled reads a coordinate written by PutCoordScaled with the same
+// scale and returns it in degrees, then advances the position.
+// Panics if scale is not positive and finite.
+func (b *Buffer) TakeCoordScaled(scale float64) float64 {
+	checkCoordScale("Buffer.TakeCoordScaled", scale)
+	return float64(b.TakeI32()) / scale
+}
+
+// PutLatLon writes lat then lon as PutCoord does and advances the position.
+// Panics if the write would exceed the buffer's capacity.
+func (b *Buffer) PutLatLon(lat, lon float64) {
+	if !b.checkWritable("PutLatLon", b.pos+8) {
+		return
+	}
+	b.PutCoord(lat)
+	b.PutCoord(lon)
+}
+
+// TakeLatLon reads a latitude and longitude written by PutLatLon, then
+// advances the position. The position is left unchanged if fewer than 8 bytes
+// are readable.
+func (b *Buffer) TakeLatLon() (lat, lon float64) {
+	if !b.checkReadable(8) {
+		return 0, 0
+	}
+	return b.TakeCoord(), b.TakeCoord()
+}
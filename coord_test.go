@@ -0,0 +1,66 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCoord tests scaled coordinates, rounding and saturation.
+func TestCoord(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutCoord(37.4219999)
+	b.PutCoord(-122.0840575)
+	assert.Equal(t, int32(374219999), int32(b.PeekAbsU32(0)))
+	assert.Equal(t, int32(-1220840575), int32(b.PeekAbsU32(4)))
+	b.Rewind()
+	assert.InDelta(t, 37.4219999, b.TakeCoord(), 1e-9)
+	assert.InDelta(t, -122.0840575, b.TakeCoord(), 1e-9)
+
+	// Round half to even, saturation and NaN
+	b.Clear()
+	for _, v := range []float64{0.5, 1.5, 2.5, -0.5, -2.5, 1e10, -1e10, math.Inf(1), math.NaN()} {
+		b.PutCoordScaled(v, 1)
+	}
+	b.Rewind()
+	for _, want := range []int32{0, 2, 2, 0, -2, math.MaxInt32, math.MinInt32, math.MaxInt32, 0} {
+		assert.Equal(t, want, int32(b.TakeU32()))
+	}
+
+	b.Clear()
+	b.PutCoordScaled(12.345, 1e3)
+	b.Rewind()
+	assert.Equal(t, 12.345, b.TakeCoordScaled(1e3))
+	assert.Panics(t, func() { b.PutCoordScaled(1, 0) })
+	assert.Panics(t, func() { b.TakeCoordScaled(math.NaN()) })
+}
+
+// TestLatLon tests writing and reading coordinate pairs.
+func TestLatLon(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutLatLon(-33.8688, 151.2093)
+	assert.Equal(t, 8, b.Count())
+	b.Rewind()
+	lat, lon := b.TakeLatLon()
+	assert.InDelta(t, -33.8688, lat, 1e-9)
+	assert.InDelta(t, 151.2093, lon, 1e-9)
+
+	// Incomplete pairs are neither written nor read
+	fixed := NewBuffer(6)
+	fixed.SetStrictMode(true)
+	fixed.PutLatLon(1, 2)
+	assert.ErrorIs(t, fixed.Err(), ErrOutOfRange)
+	assert.Equal(t, 0, fixed.Pos())
+
+	r := NewBufferFrom(make([]byte, 6))
+	r.SetStrictMode(true)
+	lat, lon = r.TakeLatLon()
+	assert.Equal(t, 0.0, lat+lon)
+	assert.Equal(t, 0, r.Pos())
+}
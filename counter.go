@@ -0,0 +1,287 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Putter is the write API shared by Buffer, Builder and SizeCounter. A
+// serialization function written against it can be run on a SizeCounter to
+// measure the exact encoded size, then again on a Builder reserved to that
+// size.
+type Putter interface {
+	ArrayPutter
+	PutU8(v uint8)
+	PutU16(v uint16)
+	PutU24(v uint32)
+	PutU32(v uint32)
+	PutU64(v uint64)
+	PutU16In(v uint16, e Endian)
+	PutU32In(v uint32, e Endian)
+	PutU64In(v uint64, e Endian)
+	PutU16LE(v uint16)
+	PutU16BE(v uint16)
+	PutU32LE(v uint32)
+	PutU32BE(v uint32)
+	PutU64LE(v uint64)
+	PutU64BE(v uint64)
+	PutI8(v int8)
+	PutI16(v int16)
+	PutI32(v int32)
+	PutI64(v int64)
+	PutF32(v float32)
+	PutF64(v float64)
+	PutArrF32(v []float32)
+	PutArrF64(v []float64)
+	PutBool(v bool)
+	PutStr(s string)
+	PutSizedBytes(v []byte)
+	PutVLQ(v uint32)
+	PutUvarint(v uint64) int
+	PutVarint(v int64) int
+	PutFieldTag(fieldNum, wireType int)
+	PutEnumU8(v uint8, valid []uint8) error
+	PutEnumU16(v uint16, valid []uint16) error
+	PutTLVList(width int, records []TLV) error
+	PutUTF16String(s string)
+	PutBitsArr(nbits int, v []uint32)
+}
+
+var (
+	_ Putter = (*Buffer)(nil)
+	_ Putter = (*Builder)(nil)
+	_ Putter = (*SizeCounter)(nil)
+)
+
+// SizeCounter implements Putter by adding up the number of bytes each call
+// would write, without writing or allocating anything. Variable-width
+// encodings are sized exactly as Buffer writes them.
+type SizeCounter struct {
+	n int
+}
+
+// Len returns the number of bytes counted so far.
+func (c *SizeCounter) Len() int { return c.n }
+
+// Reset sets the count back to 0.
+func (c *SizeCounter) Reset() { c.n = 0 }
+
+// PutU8 counts 1 byte.
+func (c *SizeCounter) PutU8(v uint8) { c.n++ }
+
+// PutU16 counts 2 bytes.
+func (c *SizeCounter) PutU16(v uint16) { c.n += 2 }
+
+// PutU32 counts 4 bytes.
+func (c *SizeCounter) PutU32(v uint32) { c.n += 4 }
+
+// PutU64 counts 8 bytes.
+func (c *SizeCounter) PutU64(v uint64) { c.n += 8 }
+
+// PutU24 counts 3 bytes. Panics if v exceeds MaxU24, as Buffer.PutU24 does.
+func (c *SizeCounter) PutU24(v uint32) {
+	if v > MaxU24 {
+		panic(fmt.Errorf("mbuff.SizeCounter.PutU24: value 0x%X exceeds 0x%X", v, MaxU24))
+	}
+	c.n += 3
+}
+
+// PutU16In counts 2 bytes.
+func (c *SizeCounter) PutU16In(v uint16, e Endian) { c.n += 2 }
+
+// PutU32In counts 4 bytes.
+func (c *SizeCounter) PutU32In(v uint32, e Endian) { c.n += 4 }
+
+// PutU64In counts 8 bytes.
+func (c *SizeCounter) PutU64In(v uint64, e Endian) { c.n += 8 }
+
+// PutU16LE counts 2 bytes.
+func (c *SizeCounter) PutU16LE(v uint16) { c.n += 2 }
+
+// PutU16BE counts 2 bytes.
+func (c *SizeCounter) PutU16BE(v uint16) { c.n += 2 }
+
+// PutU32LE counts 4 bytes.
+func (c *SizeCounter) PutU32LE(v uint32) { c.n += 4 }
+
+// PutU32BE counts 4 bytes.
+func (c *SizeCounter) PutU32BE(v uint32) { c.n += 4 }
+
+// PutU64LE counts 8 bytes.
+func (c *SizeCounter) PutU64LE(v uint64) { c.n += 8 }
+
+// PutU64BE counts 8 bytes.
+func (c *SizeCounter) PutU64BE(v uint64) { c.n += 8 }
+
+// PutI8 counts 1 byte.
+func (c *SizeCounter) PutI8(v int8) { c.n++ }
+
+// PutI16 counts 2 bytes.
+func (c *SizeCounter) PutI16(v int16) { c.n += 2 }
+
+// PutI32 counts 4 bytes.
+func (c *SizeCounter) PutI32(v int32) { c.n += 4 }
+
+// PutI64 counts 8 bytes.
+func (c *SizeCounter) PutI64(v int64) { c.n += 8 }
+
+// PutF32 counts 4 bytes.
+func (c *SizeCounter) PutF32(v float32) { c.n += 4 }
+
+// PutF64 counts 8 bytes.
+func (c *SizeCounter) PutF64(v float64) { c.n += 8 }
+
+// PutBool counts 1 byte.
+func (c *SizeCounter) PutBool(v bool) { c.n++ }
+
+// PutArr8 counts len(v) bytes.
+func (c *SizeCounter) PutArr8(v []byte) { c.n += len(v) }
+
+// PutArr16 counts 2 bytes per element.
+func (c *SizeCounter) PutArr16(v []uint16) { c.n += len(v) << 1 }
+
+// PutArr32 counts 4 bytes per element.
+func (c *SizeCounter) PutArr32(v []uint32) { c.n += len(v) << 2 }
+
+// PutArr64 counts 8 bytes per element.
+func (c *SizeCounter) PutArr64(v []uint64) { c.n += len(v) << 3 }
+
+// PutArrF32 counts 4 bytes per element.
+func (c *SizeCounter) PutArrF32(v []float32) { c.n += len(v) << 2 }
+
+// PutArrF64 counts 8 bytes per element.
+func (c *SizeCounter) PutArrF64(v []float64) { c.n += len(v) << 3 }
+
+// PutStr counts len(s) bytes.
+func (c *SizeCounter) PutStr(s string) { c.n += len(s) }
+
+// PutSizedBytes counts v and its length prefix as written by Buffer.PutSizedBytes.
+func (c *SizeCounter) PutSizedBytes(v []byte) { c.n += sizedPrefixLen(len(v)) + len(v) }
+
+// PutVLQ counts the encoded length of v as written by Buffer.PutVLQ.
+func (c *SizeCounter) PutVLQ(v uint32) { c.n += vlqLen(v) }
+
+// PutUvarint counts and returns the encoded length of v as written by
+// Buffer.PutUvarint.
+func (c *SizeCounter) PutUvarint(v uint64) int {
+	n := UvarintLen(v)
+	c.n += n
+	return n
+}
+
+// PutVarint counts and returns the encoded length of v as written by
+// Buffer.PutVarint.
+func (c *SizeCounter) PutVarint(v int64) int {
+	n := VarintLen(v)
+	c.n += n
+	return n
+}
+
+// PutFieldTag counts the tag as written by Buffer.PutFieldTag.
+// Panics if fieldNum or wireType is invalid, as Buffer.PutFieldTag does.
+func (c *SizeCounter) PutFieldTag(fieldNum, wireType int) {
+	if err := checkFieldTag("SizeCounter.PutFieldTag", fieldNum, wireType); err != nil {
+		panic(err)
+	}
+	c.n += UvarintLen(uint64(fieldNum)<<3 | uint64(wireType))
+}
+
+// PutEnumU8 counts 1 byte if v is one of the allowed values.
+// Returns an error, counting nothing, otherwise.
+func (c *SizeCounter) PutEnumU8(v uint8, valid []uint8) error {
+	if !slices.Contains(valid, v) {
+		return fmt.Errorf("mbuff.SizeCounter.PutEnumU8: unknown value %d", v)
+	}
+	c.n++
+	return nil
+}
+
+// PutEnumU16 counts 2 bytes if v is one of the allowed values.
+// Returns an error, counting nothing, otherwise.
+func (c *SizeCounter) PutEnumU16(v uint16, valid []uint16) error {
+	if !slices.Contains(valid, v) {
+		return fmt.Errorf("mbuff.SizeCounter.PutEnumU16: unknown value %d", v)
+	}
+	c.n += 2
+	return nil
+}
+
+// PutTLVList counts records as written by Buffer.PutTLVList.
+// Returns an error, counting nothing, if Buffer.PutTLVList would reject them.
+func (c *SizeCounter) PutTLVList(width int, records []TLV) error {
+	total, err := tlvListLen("SizeCounter.PutTLVList", width, records)
+	if err != nil {
+		return err
+	}
+	c.n += total
+	return nil
+}
+
+// PutUTF16String counts s as written by Buffer.PutUTF16String.
+func (c *SizeCounter) PutUTF16String(s string) {
+	c.n += 2
+	for _, r := range s {
+		if r >= 0x10000 {
+			c.n += 4 // surrogate pair
+		} else {
+			c.n += 2
+		}
+	}
+}
+
+// PutBitsArr counts v as written by Buffer.PutBitsArr.
+// Panics if nbits is not in 1..32.
+func (c *SizeCounter) PutBitsArr(nbits int, v []uint32) {
+	checkBitWidth("SizeCounter.PutBitsArr", nbits)
+	c.n += bitsLen(nbits, len(v))
+}
+
+// SizePlan adds up the size of a series of writes described by field counts
+// rather than values, for encoders whose layout is known statically. Unlike
+// SizeCounter, it needs no dry run. Its Add methods return the plan so calls
+// can be chained, and panic if n is negative:
+//
+//	var p mbuff.SizePlan
+//	b.Grow(p.AddU8(1).AddU32(3).AddBytes(len(payload)).Total())
+type SizePlan struct {
+	n int
+}
+
+// add adds n elements of the given size.
+func (p *SizePlan) add(method string, n, size int) *SizePlan {
+	if n < 0 {
+		panic("mbuff.SizePlan." + method + ": negative count")
+	}
+	p.n += n * size
+	return p
+}
+
+// AddU8 plans n uint8 values.
+func (p *SizePlan) AddU8(n int) *SizePlan { return p.add("AddU8", n, 1) }
+
+// AddU16 plans n uint16 values.
+func (p *SizePlan) AddU16(n int) *SizePlan { return p.add("AddU16", n, 2) }
+
+// AddU32 plans n uint32 values.
+func (p *SizePlan) AddU32(n int) *SizePlan { return p.add("AddU32", n, 4) }
+
+// AddU64 plans n uint64 values.
+func (p *SizePlan) AddU64(n int) *SizePlan { return p.add("AddU64", n, 8) }
+
+// AddBytes plans n raw bytes, e.g. a PutArr8 or PutStr of that length.
+func (p *SizePlan) AddBytes(n int) *SizePlan { return p.add("AddBytes", n, 1) }
+
+// AddSizedBytes plans a PutSizedBytes of n bytes, including its length prefix.
+func (p *SizePlan) AddSizedBytes(n int) *SizePlan {
+	p.add("AddSizedBytes", n, 1)
+	p.n += sizedPrefixLen(n)
+	return p
+}
+
+// Total returns the number of bytes planned so far.
+func (p *SizePlan) Total() int { return p.n }
@@ -0,0 +1,137 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// putterCalls holds one of every Putter call, each with inputs whose size
+// depends on n.
+var putterCalls = []struct {
+	name string
+	put  func(w Putter, n int)
+}{
+	{"PutU8", func(w Putter, n int) { w.PutU8(1) }},
+	{"PutU16", func(w Putter, n int) { w.PutU16(2) }},
+	{"PutU24", func(w Putter, n int) { w.PutU24(MaxU24) }},
+	{"PutU32", func(w Putter, n int) { w.PutU32(3) }},
+	{"PutU64", func(w Putter, n int) { w.PutU64(4) }},
+	{"PutU16In", func(w Putter, n int) { w.PutU16In(5, LittleEndian) }},
+	{"PutU32In", func(w Putter, n int) { w.PutU32In(6, LittleEndian) }},
+	{"PutU64In", func(w Putter, n int) { w.PutU64In(7, BigEndian) }},
+	{"PutU16LE", func(w Putter, n int) { w.PutU16LE(8) }},
+	{"PutU16BE", func(w Putter, n int) { w.PutU16BE(8) }},
+	{"PutU32LE", func(w Putter, n int) { w.PutU32LE(9) }},
+	{"PutU32BE", func(w Putter, n int) { w.PutU32BE(9) }},
+	{"PutU64LE", func(w Putter, n int) { w.PutU64LE(10) }},
+	{"PutU64BE", func(w Putter, n int) { w.PutU64BE(10) }},
+	{"PutI8", func(w Putter, n int) { w.PutI8(-1) }},
+	{"PutI16", func(w Putter, n int) { w.PutI16(-2) }},
+	{"PutI32", func(w Putter, n int) { w.PutI32(-3) }},
+	{"PutI64", func(w Putter, n int) { w.PutI64(-4) }},
+	{"PutF32", func(w Putter, n int) { w.PutF32(1.5) }},
+	{"PutF64", func(w Putter, n int) { w.PutF64(2.5) }},
+	{"PutBool", func(w Putter, n int) { w.PutBool(true) }},
+	{"PutArr8", func(w Putter, n int) { w.PutArr8(make([]byte, n)) }},
+	{"PutArr16", func(w Putter, n int) { w.PutArr16(make([]uint16, n)) }},
+	{"PutArr32", func(w Putter, n int) { w.PutArr32(make([]uint32, n)) }},
+	{"PutArr64", func(w Putter, n int) { w.PutArr64(make([]uint64, n)) }},
+	{"PutArrF32", func(w Putter, n int) { w.PutArrF32(make([]float32, n)) }},
+	{"PutArrF64", func(w Putter, n int) { w.PutArrF64(make([]float64, n)) }},
+	{"PutStr", func(w Putter, n int) { w.PutStr(strings.Repeat("x", n)) }},
+	{"PutSizedBytes", func(w Putter, n int) { w.PutSizedBytes(make([]byte, n*100)) }},
+	{"PutVLQ", func(w Putter, n int) { w.PutVLQ(uint32(n) << 14) }},
+	{"PutUvarint", func(w Putter, n int) { w.PutUvarint(uint64(n) << 20) }},
+	{"PutVarint", func(w Putter, n int) { w.PutVarint(-int64(n) << 20) }},
+	{"PutFieldTag", func(w Putter, n int) { w.PutFieldTag(n+1, WireBytes) }},
+	{"PutEnumU8", func(w Putter, n int) { _ = w.PutEnumU8(2, []uint8{1, 2}) }},
+	{"PutEnumU16", func(w Putter, n int) { _ = w.PutEnumU16(3, []uint16{1, 2}) }}, // rejected
+	{"PutTLVList", func(w Putter, n int) {
+		_ = w.PutTLVList(2, []TLV{{Type: 1, Value: make([]byte, n)}, {Type: 2}})
+	}},
+	{"PutUTF16String", func(w Putter, n int) { w.PutUTF16String(strings.Repeat("é😀", n)) }},
+	{"PutBitsArr", func(w Putter, n int) { w.PutBitsArr(12, make([]uint32, n)) }},
+}
+
+// encodeSample writes one of every Putter call with variable-width inputs.
+func encodeSample(w Putter, n int) {
+	for _, c := range putterCalls {
+		c.put(w, n)
+	}
+}
+
+// TestSizeCounter tests that the counted size matches the written size.
+func TestSizeCounter(t *testing.T) {
+	for _, n := range []int{0, 1, 3, 700} {
+		var c SizeCounter
+		encodeSample(&c, n)
+
+		b := NewBuilder(0)
+		b.Reserve(c.Len())
+		capBefore := b.Capacity()
+		encodeSample(b, n)
+		assert.Equal(t, c.Len(), b.Count(), "n %d", n)
+		assert.Equal(t, capBefore, b.Capacity(), "no reallocation after Reserve")
+
+		c.Reset()
+		assert.Equal(t, 0, c.Len())
+	}
+	assert.Panics(t, func() { new(SizeCounter).PutBitsArr(0, nil) })
+}
+
+// TestSizeCounter_Methods tests that SizeCounter and Builder agree on the
+// size of each Putter method, and on what is rejected.
+func TestSizeCounter_Methods(t *testing.T) {
+	for _, call := range putterCalls {
+		for _, n := range []int{0, 1, 300} {
+			var c SizeCounter
+			call.put(&c, n)
+			b := NewBuilder(0)
+			call.put(b, n)
+			assert.Equal(t, b.Count(), c.Len(), "%s, n %d", call.name, n)
+		}
+	}
+
+	var c SizeCounter
+	assert.Equal(t, 3, c.PutUvarint(1<<14))
+	assert.Equal(t, 1, c.PutVarint(-64))
+	assert.Error(t, c.PutEnumU8(3, []uint8{1, 2}))
+	assert.Error(t, c.PutTLVList(1, []TLV{{Type: 256}}))
+	assert.Error(t, c.PutTLVList(3, nil))
+	assert.Equal(t, 4, c.Len())
+	assert.Panics(t, func() { c.PutU24(MaxU24 + 1) })
+	assert.Panics(t, func() { c.PutFieldTag(0, WireVarint) })
+	assert.Panics(t, func() { c.PutFieldTag(1, 6) })
+}
+
+// TestSizePlan tests that a planned size matches the written size.
+func TestSizePlan(t *testing.T) {
+	for _, n := range []int{0, 1, 3, 700} {
+		var p SizePlan
+		p.AddU8(1).AddU16(1).AddU32(1).AddU64(1)
+		p.AddBytes(n).AddU16(n).AddU32(n).AddU64(n).AddBytes(n)
+		p.AddSizedBytes(n * 100)
+
+		var c SizeCounter
+		c.PutU8(1)
+		c.PutU16(2)
+		c.PutU32(3)
+		c.PutU64(4)
+		c.PutArr8(make([]byte, n))
+		c.PutArr16(make([]uint16, n))
+		c.PutArr32(make([]uint32, n))
+		c.PutArr64(make([]uint64, n))
+		c.PutStr(strings.Repeat("x", n))
+		c.PutSizedBytes(make([]byte, n*100))
+		assert.Equal(t, c.Len(), p.Total(), "n %d", n)
+	}
+	assert.Equal(t, 0, new(SizePlan).Total())
+	assert.Panics(t, func() { new(SizePlan).AddU32(-1) })
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Entry flags used by DictEncoder and DictDecoder.
+const (
+	dictLiteral = 0x00 // flag followed by the blob as written by PutSizedBytes
+	dictRef     = 0x01 // flag followed by the dictionary index as a VLQ
+)
+
+// DictEncoder writes byte blobs with dictionary compression: the first
+// occurrence of a blob is written as a literal and assigned the next index,
+// and later occurrences are written as a reference to that index.
+//
+// Each entry is either
+//
+//	[0x00] [blob as written by PutSizedBytes]
+//	[0x01] [index:VLQ]
+//
+// The dictionary holds at most maxEntries blobs. Once it is full, each new
+// literal replaces the oldest entry (first in, first out) and takes over its
+// index. A DictDecoder must be created with the same maxEntries.
+type DictEncoder struct {
+	b     *Builder
+	max   int
+	index map[string]int
+	slots []string
+	next  int
+}
+
+// NewDictEncoder creates an encoder writing to b with a dictionary of at
+// most maxEntries blobs. Panics if maxEntries is not positive.
+func NewDictEncoder(b *Builder, maxEntries int) *DictEncoder {
+	if maxEntries <= 0 {
+		panic("mbuff.NewDictEncoder: non-positive max entries")
+	}
+	return &DictEncoder{b: b, max: maxEntries, index: make(map[string]int)}
+}
+
+// WriteBlob writes v as a reference if it is in the dictionary, otherwise
+// as a literal that is added to the dictionary.
+func (e *DictEncoder) WriteBlob(v []byte) {
+	if i, ok := e.index[string(v)]; ok {
+		e.b.PutU8(dictRef)
+		e.b.PutVLQ(uint32(i))
+		return
+	}
+	e.b.PutU8(dictLiteral)
+	e.b.PutSizedBytes(v)
+
+	s := string(v)
+	if len(e.slots) < e.max {
+		e.index[s] = len(e.slots)
+		e.slots = append(e.slots, s)
+		return
+	}
+	delete(e.index, e.slots[e.next])
+	e.index[s] = e.next
+	e.slots[e.next] = s
+	e.next = (e.next + 1) % e.max
+}
+
+// DictDecoder reads byte blobs written by a DictEncoder.
+type DictDecoder struct {
+	b     *Buffer
+	max   int
+	slots [][]byte
+	next  int
+}
+
+// NewDictDecoder creates a decoder reading from b with a dictionary of at
+// most maxEntries blobs, matching the encoder. Panics if maxEntries is not
+// positive.
+func NewDictDecoder(b *Buffer, maxEntries int) *DictDecoder {
+	if maxEntries <= 0 {
+		panic("mbuff.NewDictDecoder: non-positive max entries")
+	}
+	return &DictDecoder{b: b, max: maxEntries}
+}
+
+// ReadBlob reads the next blob. A literal is returned as a new slice owned by
+// the caller, while the dictionary keeps its own copy. Blobs returned for a
+// reference share memory with the dictionary and must not be modified.
+// In error mode, the position is left unchanged if the entry is malformed or
+// truncated.
+func (d *DictDecoder) ReadBlob() []byte {
+	b := d.b
+	if b.err != nil {
+		return nil
+	}
+	start := b.pos
+	flag := b.TakeU8()
+	if b.err != nil {
+		return nil
+	}
+	switch flag {
+	case dictLiteral:
+		v := b.TakeSizedBytes()
+		if b.err != nil {
+			b.setPos(start)
+			return nil
+		}
+		entry := bytes.Clone(v)
+		if len(d.slots) < d.max {
+			d.slots = append(d.slots, entry)
+		} else {
+			d.slots[d.next] = entry
+			d.next = (d.next + 1) % d.max
+		}
+		return v
+	case dictRef:
+		i, _ := b.TakeVLQ()
+		if b.err != nil {
+			b.setPos(start)
+			return nil
+		}
+		if int(i) >= len(d.slots) {
+			b.setPos(start)
+			b.fail(fmt.Errorf("mbuff.DictDecoder.ReadBlob: reference %d at pos %d exceeds dictionary size %d", i, start, len(d.slots)))
+			return nil
+		}
+		return d.slots[i]
+	default:
+		b.setPos(start)
+		b.fail(fmt.Errorf("mbuff.DictDecoder.ReadBlob: unknown flag 0x%02X at pos %d", flag, start))
+		return nil
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDict tests dictionary encoding round trips and the wire format.
+func TestDict(t *testing.T) {
+	blobs := [][]byte{
+		[]byte("alpha"), []byte("beta"), []byte("alpha"), {}, []byte("beta"), {},
+	}
+
+	b := NewBuilder(0)
+	e := NewDictEncoder(b, 8)
+	for _, v := range blobs {
+		e.WriteBlob(v)
+	}
+	expected := []byte{
+		0x00, 5, 'a', 'l', 'p', 'h', 'a',
+		0x00, 4, 'b', 'e', 't', 'a',
+		0x01, 0,
+		0x00, 0,
+		0x01, 1,
+		0x01, 2,
+	}
+	assert.Equal(t, expected, b.Bytes())
+
+	b.Rewind()
+	d := NewDictDecoder(&b.Buffer, 8)
+	for _, v := range blobs {
+		assert.Equal(t, v, d.ReadBlob())
+	}
+	assert.Equal(t, 0, b.Readable())
+
+	// Modifying a literal does not affect later references
+	b.Rewind()
+	d = NewDictDecoder(&b.Buffer, 8)
+	first := d.ReadBlob()
+	first[0] = 'X'
+	d.ReadBlob()
+	assert.Equal(t, []byte("alpha"), d.ReadBlob())
+
+	assert.Panics(t, func() { NewDictEncoder(b, 0) })
+	assert.Panics(t, func() { NewDictDecoder(&b.Buffer, -1) })
+}
+
+// TestDict_Eviction tests that a full dictionary replaces its oldest entry.
+func TestDict_Eviction(t *testing.T) {
+	blobs := [][]byte{
+		[]byte("a"), []byte("b"), []byte("c"), // c evicts a from index 0
+		[]byte("c"), []byte("b"), []byte("a"), // a evicts b from index 1
+		[]byte("b"),
+	}
+
+	b := NewBuilder(0)
+	e := NewDictEncoder(b, 2)
+	for _, v := range blobs {
+		e.WriteBlob(v)
+	}
+	expected := []byte{
+		0x00, 1, 'a',
+		0x00, 1, 'b',
+		0x00, 1, 'c',
+		0x01, 0,
+		0x01, 1,
+		0x00, 1, 'a',
+		0x00, 1, 'b',
+	}
+	assert.Equal(t, expected, b.Bytes())
+
+	b.Rewind()
+	d := NewDictDecoder(&b.Buffer, 2)
+	for _, v := range blobs {
+		assert.Equal(t, v, d.ReadBlob())
+	}
+}
+
+// TestDict_Malformed tests decoding of invalid entries.
+func TestDict_Malformed(t *testing.T) {
+	// Reference to an index that was never defined
+	b := NewBufferFrom([]byte{0x00, 1, 'x', 0x01, 1})
+	d := NewDictDecoder(b, 4)
+	assert.Equal(t, []byte("x"), d.ReadBlob())
+	assert.Panics(t, func() { d.ReadBlob() })
+	assert.Equal(t, 3, b.Pos())
+
+	// Unknown flag
+	b = NewBufferFrom([]byte{0x02})
+	b.SetStrictMode(true)
+	d = NewDictDecoder(b, 4)
+	assert.Nil(t, d.ReadBlob())
+	assert.ErrorContains(t, b.Err(), "unknown flag")
+	assert.Equal(t, 0, b.Pos())
+
+	// Truncated literal
+	b = NewBufferFrom([]byte{0x00, 3, 'x'})
+	b.SetStrictMode(true)
+	d = NewDictDecoder(b, 4)
+	assert.Nil(t, d.ReadBlob())
+	assert.ErrorIs(t, b.Err(), ErrOutOfRange)
+	assert.Equal(t, 0, b.Pos())
+}
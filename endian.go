@@ -5,6 +5,11 @@
 
 package mbuff
 
+import (
+	"encoding/binary"
+	"fmt"
+)
+
 // Endian represents byte order for multi-byte values.
 type Endian bool
 
@@ -14,3 +19,90 @@ const (
 	// LittleEndian represents little-endian byte order.
 	LittleEndian Endian = true
 )
+
+// byteOrder returns the binary.ByteOrder for e.
+func (e Endian) byteOrder() binary.ByteOrder {
+	if e == LittleEndian {
+		return binary.LittleEndian
+	}
+	return binary.BigEndian
+}
+
+// PeekEndianBOM reports the byte order indicated by a byte-order mark at the
+// current position: FE FF for big-endian and FF FE for little-endian. Neither
+// the position nor the buffer's byte order is changed, so it suits formats
+// where the mark is optional. Returns an error if no recognized mark is present.
+func (b *Buffer) PeekEndianBOM() (Endian, error) {
+	if b.Readable() < 2 {
+		return BigEndian, fmt.Errorf("mbuff.Buffer.PeekEndianBOM: need 2 bytes at pos %d, have %d: %w", b.pos, b.Readable(), ErrOutOfRange)
+	}
+	switch c0, c1 := b.data[b.pos], b.data[b.pos+1]; {
+	case c0 == 0xFE && c1 == 0xFF:
+		return BigEndian, nil
+	case c0 == 0xFF && c1 == 0xFE:
+		return LittleEndian, nil
+	default:
+		return BigEndian, fmt.Errorf("mbuff.Buffer.PeekEndianBOM: unrecognized byte-order mark %02X %02X at pos %d", c0, c1, b.pos)
+	}
+}
+
+// DetectEndianBOM reads a byte-order mark at the current position, sets the
+// buffer's byte order accordingly and advances past the mark. Returns an
+// error, changing nothing, if no recognized mark is present.
+func (b *Buffer) DetectEndianBOM() (Endian, error) {
+	e, err := b.PeekEndianBOM()
+	if err != nil {
+		return e, err
+	}
+	b.SetEndian(e)
+	b.pos += 2
+	return e, nil
+}
+
+// PutU16LE writes a little-endian uint16 at the current position and
+// advances the position, regardless of the buffer's byte order.
+func (b *Buffer) PutU16LE(v uint16) { b.PutU16In(v, LittleEndian) }
+
+// PutU16BE writes a big-endian uint16 at the current position and
+// advances the position, regardless of the buffer's byte order.
+func (b *Buffer) PutU16BE(v uint16) { b.PutU16In(v, BigEndian) }
+
+// PutU32LE writes a little-endian uint32 at the current position and
+// advances the position, regardless of the buffer's byte order. High-low swap still applies.
+func (b *Buffer) PutU32LE(v uint32) { b.PutU32In(v, LittleEndian) }
+
+// PutU32BE writes a big-endian uint32 at the current position and
+// advances the position, regardless of the buffer's byte order. High-low swap still applies.
+func (b *Buffer) PutU32BE(v uint32) { b.PutU32In(v, BigEndian) }
+
+// PutU64LE writes a little-endian uint64 at the current position and
+// advances the position, regardless of the buffer's byte order. High-low swap still applies.
+func (b *Buffer) PutU64LE(v uint64) { b.PutU64In(v, LittleEndian) }
+
+// PutU64BE writes a big-endian uint64 at the current position and
+// advances the position, regardless of the buffer's byte order. High-low swap still applies.
+func (b *Buffer) PutU64BE(v uint64) { b.PutU64In(v, BigEndian) }
+
+// TakeU16LE reads a little-endian uint16 at the current position, then
+// advances the position, regardless of the buffer's byte order.
+func (b *Buffer) TakeU16LE() uint16 { return b.TakeU16In(LittleEndian) }
+
+// TakeU16BE reads a big-endian uint16 at the current position, then
+// advances the position, regardless of the buffer's byte order.
+func (b *Buffer) TakeU16BE() uint16 { return b.TakeU16In(BigEndian) }
+
+// TakeU32LE reads a little-endian uint32 at the current position, then
+// advances the position, regardless of the buffer's byte order. High-low swap still applies.
+func (b *Buffer) TakeU32LE() uint32 { return b.TakeU32In(LittleEndian) }
+
+// TakeU32BE reads a big-endian uint32 at the current position, then
+// advances the position, regardless of the buffer's byte order. High-low swap still applies.
+func (b *Buffer) TakeU32BE() uint32 { return b.TakeU32In(BigEndian) }
+
+// TakeU64LE reads a little-endian uint64 at the current position, then
+// advances the position, regardless of the buffer's byte order. High-low swap still applies.
+func (b *Buffer) TakeU64LE() uint64 { return b.TakeU64In(LittleEndian) }
+
+// TakeU64BE reads a big-endian uint64 at the current position, then
+// advances the position, regardless of the buffer's byte order. High-low swap still applies.
+func (b *Buffer) TakeU64BE() uint64 { return b.TakeU64In(BigEndian) }
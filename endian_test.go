@@ -33,3 +33,110 @@ func TestEndian(t *testing.T) {
 	v := b.TakeU32()
 	assert.Equal(t, uint32(0x12345678), v)
 }
+
+// TestEndianPerCall tests per-call byte order without changing the buffer's.
+func TestEndianPerCall(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutU16In(0x0102, LittleEndian)
+	b.PutU32In(0x03040506, LittleEndian)
+	b.PutU64In(0x0708090A0B0C0D0E, BigEndian)
+	assert.Equal(t, BigEndian, b.GetEndian())
+	assert.Equal(t, []byte{
+		0x02, 0x01,
+		0x06, 0x05, 0x04, 0x03,
+		0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E,
+	}, b.Bytes())
+
+	b.Rewind()
+	assert.Equal(t, uint16(0x0201), b.PeekU16In(0, BigEndian))
+	assert.Equal(t, uint32(0x03040506), b.PeekU32In(2, LittleEndian))
+	assert.Equal(t, uint64(0x0E0D0C0B0A090807), b.PeekU64In(6, LittleEndian))
+	assert.Equal(t, uint16(0x0102), b.TakeU16In(LittleEndian))
+	assert.Equal(t, uint32(0x03040506), b.TakeU32In(LittleEndian))
+	assert.Equal(t, uint64(0x0708090A0B0C0D0E), b.TakeU64In(BigEndian))
+
+	// High-low swap follows the buffer flag
+	b.Clear()
+	b.SetHLSwap(true)
+	b.PutU32In(0x11223344, LittleEndian)
+	assert.Equal(t, []byte{0x33, 0x44, 0x11, 0x22}, b.Bytes())
+	b.Rewind()
+	assert.Equal(t, uint32(0x11223344), b.TakeU32In(LittleEndian))
+
+	fixed := NewBuffer(1)
+	assert.Panics(t, func() { fixed.PutU16In(0, LittleEndian) })
+	assert.Panics(t, func() { fixed.TakeU16In(LittleEndian) })
+}
+
+// TestEndianBOM tests detecting the byte order from a byte-order mark.
+func TestEndianBOM(t *testing.T) {
+	b := NewBufferFrom([]byte{0xFF, 0xFE, 0x34, 0x12})
+	e, err := b.PeekEndianBOM()
+	assert.NoError(t, err)
+	assert.Equal(t, LittleEndian, e)
+	assert.Equal(t, 0, b.Pos())
+	assert.Equal(t, BigEndian, b.GetEndian())
+
+	e, err = b.DetectEndianBOM()
+	assert.NoError(t, err)
+	assert.Equal(t, LittleEndian, e)
+	assert.Equal(t, LittleEndian, b.GetEndian())
+	assert.Equal(t, uint16(0x1234), b.TakeU16())
+
+	b = NewBufferFrom([]byte{0xFE, 0xFF, 0x12, 0x34})
+	b.SetEndian(LittleEndian)
+	e, err = b.DetectEndianBOM()
+	assert.NoError(t, err)
+	assert.Equal(t, BigEndian, e)
+	assert.Equal(t, uint16(0x1234), b.TakeU16())
+
+	// Missing or truncated marks change nothing
+	b = NewBufferFrom([]byte{0x12, 0x34})
+	_, err = b.DetectEndianBOM()
+	assert.ErrorContains(t, err, "unrecognized byte-order mark 12 34")
+	assert.Equal(t, 0, b.Pos())
+	b = NewBufferFrom([]byte{0xFE})
+	_, err = b.PeekEndianBOM()
+	assert.ErrorIs(t, err, ErrOutOfRange)
+}
+
+// TestEndianFixed tests the LE and BE per-call variants.
+func TestEndianFixed(t *testing.T) {
+	b := NewBuilder(0)
+	b.SetEndian(LittleEndian)
+	b.PutU16BE(0x0102)
+	b.PutU16LE(0x0304)
+	b.PutU32BE(0x05060708)
+	b.PutU32LE(0x090A0B0C)
+	b.PutU64BE(0x0102030405060708)
+	b.PutU64LE(0x0102030405060708)
+	assert.Equal(t, LittleEndian, b.GetEndian())
+	assert.Equal(t, []byte{
+		0x01, 0x02,
+		0x04, 0x03,
+		0x05, 0x06, 0x07, 0x08,
+		0x0C, 0x0B, 0x0A, 0x09,
+		0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+		0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01,
+	}, b.Bytes())
+
+	b.Rewind()
+	assert.Equal(t, uint16(0x0102), b.TakeU16BE())
+	assert.Equal(t, uint16(0x0304), b.TakeU16LE())
+	assert.Equal(t, uint32(0x05060708), b.TakeU32BE())
+	assert.Equal(t, uint32(0x090A0B0C), b.TakeU32LE())
+	assert.Equal(t, uint64(0x0102030405060708), b.TakeU64BE())
+	assert.Equal(t, uint64(0x0102030405060708), b.TakeU64LE())
+
+	// High-low swap still applies
+	b.Clear()
+	b.SetHLSwap(true)
+	b.PutU32BE(0x11223344)
+	assert.Equal(t, []byte{0x22, 0x11, 0x44, 0x33}, b.Bytes())
+	b.Rewind()
+	assert.Equal(t, uint32(0x11223344), b.TakeU32BE())
+
+	fixed := NewBuffer(1)
+	assert.Panics(t, func() { fixed.PutU32LE(0) })
+	assert.Panics(t, func() { fixed.TakeU64BE() })
+}
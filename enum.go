@@ -0,0 +1,59 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"fmt"
+	"slices"
+)
+
+// TakeEnumU8 reads a uint8 at the current position and checks it against
+// the allowed values. The position is advanced only if the value is valid.
+func (b *Buffer) TakeEnumU8(valid []uint8) (uint8, error) {
+	if !b.checkReadable(1) {
+		return 0, b.err
+	}
+	v := b.data[b.pos]
+	if !slices.Contains(valid, v) {
+		return v, fmt.Errorf("mbuff.Buffer.TakeEnumU8: unknown value %d at pos %d", v, b.pos)
+	}
+	b.pos += 1
+	return v, nil
+}
+
+// TakeEnumU16 reads a uint16 at the current position and checks it against
+// the allowed values. The position is advanced only if the value is valid.
+func (b *Buffer) TakeEnumU16(valid []uint16) (uint16, error) {
+	if !b.checkReadable(2) {
+		return 0, b.err
+	}
+	v := b.order.Uint16(b.data[b.pos : b.pos+2])
+	if !slices.Contains(valid, v) {
+		return v, fmt.Errorf("mbuff.Buffer.TakeEnumU16: unknown value %d at pos %d", v, b.pos)
+	}
+	b.pos += 2
+	return v, nil
+}
+
+// PutEnumU8 writes v at the current position if it is one of the allowed
+// values, then advances the position. Nothing is written otherwise.
+func (b *Buffer) PutEnumU8(v uint8, valid []uint8) error {
+	if !slices.Contains(valid, v) {
+		return fmt.Errorf("mbuff.Buffer.PutEnumU8: unknown value %d", v)
+	}
+	b.PutU8(v)
+	return b.err
+}
+
+// PutEnumU16 writes v at the current position if it is one of the allowed
+// values, then advances the position. Nothing is written otherwise.
+func (b *Buffer) PutEnumU16(v uint16, valid []uint16) error {
+	if !slices.Contains(valid, v) {
+		return fmt.Errorf("mbuff.Buffer.PutEnumU16: unknown value %d", v)
+	}
+	b.PutU16(v)
+	return b.err
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEnum tests validated enum reads and writes.
+func TestEnum(t *testing.T) {
+	kinds := []uint8{1, 2, 4}
+	codes := []uint16{0x0100, 0x0200}
+
+	// Rejected values do not grow the buffer
+	b := NewBuilder(0)
+	assert.Error(t, b.PutEnumU8(3, kinds))
+	assert.Error(t, b.PutEnumU16(0x0300, codes))
+	assert.Equal(t, 0, b.Capacity())
+
+	assert.NoError(t, b.PutEnumU8(2, kinds))
+	assert.NoError(t, b.PutEnumU16(0x0200, codes))
+	assert.Error(t, b.PutEnumU8(3, kinds))
+	assert.Error(t, b.PutEnumU16(0x0300, codes))
+	assert.Equal(t, []byte{0x02, 0x02, 0x00}, b.Bytes())
+
+	b.PutU8(3)
+	b.Rewind()
+	v8, err := b.TakeEnumU8(kinds)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(2), v8)
+	v16, err := b.TakeEnumU16(codes)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(0x0200), v16)
+
+	// Unknown values do not advance
+	v8, err = b.TakeEnumU8(kinds)
+	assert.Error(t, err)
+	assert.Equal(t, uint8(3), v8)
+	assert.Equal(t, 3, b.Pos())
+	assert.Panics(t, func() { _, _ = b.TakeEnumU16(codes) })
+
+	// Bounds violations surface as the sticky error in error mode
+	b.SetStrictMode(true)
+	_, err = b.TakeEnumU16(codes)
+	assert.Error(t, err)
+	assert.Equal(t, err, b.Err())
+}
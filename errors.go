@@ -0,0 +1,20 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"errors"
+)
+
+// ErrOutOfRange is wrapped by the errors of bounds-checked operations, both
+// those returned by the Try methods and those recorded in error mode, so
+// callers can test for it with errors.Is.
+var ErrOutOfRange = errors.New("mbuff: out of range")
+
+// ErrChecksum is wrapped by the errors of decoders that find a stored
+// checksum that does not match the data, so callers can tell corruption
+// apart from truncation with errors.Is.
+var ErrChecksum = errors.New("mbuff: checksum mismatch")
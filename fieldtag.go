@@ -0,0 +1,81 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Protobuf wire types, the low 3 bits of a field tag.
+const (
+	WireVarint     = 0
+	WireFixed64    = 1
+	WireBytes      = 2
+	WireStartGroup = 3
+	WireEndGroup   = 4
+	WireFixed32    = 5
+)
+
+// MaxFieldNum is the largest protobuf field number.
+const MaxFieldNum = 1<<29 - 1
+
+// checkFieldTag returns an error unless fieldNum and wireType form a valid tag.
+func checkFieldTag(method string, fieldNum, wireType int) error {
+	if fieldNum < 1 || fieldNum > MaxFieldNum {
+		return fmt.Errorf("mbuff.%s: field number %d out of range [1, %d]", method, fieldNum, MaxFieldNum)
+	}
+	if wireType < WireVarint || wireType > WireFixed32 {
+		return fmt.Errorf("mbuff.%s: invalid wire type %d", method, wireType)
+	}
+	return nil
+}
+
+// PutFieldTag writes a protobuf field tag, the uvarint of
+// fieldNum<<3 | wireType, and advances the position.
+// Panics if fieldNum is not in [1, MaxFieldNum], wireType is not a valid wire
+// type or the write would exceed the buffer's capacity, unless error mode is
+// enabled.
+func (b *Buffer) PutFieldTag(fieldNum, wireType int) {
+	if err := checkFieldTag("Buffer.PutFieldTag", fieldNum, wireType); err != nil {
+		b.fail(err)
+		return
+	}
+	tag := uint64(fieldNum)<<3 | uint64(wireType)
+	required := b.pos + UvarintLen(tag)
+	if !b.checkWritable("PutFieldTag", required) {
+		return
+	}
+
+	binary.PutUvarint(b.data[b.pos:required], tag)
+	b.pos = required
+}
+
+// TakeFieldTag reads a protobuf field tag written by PutFieldTag, then
+// advances the position. The position is left unchanged if the uvarint is
+// malformed or truncated, or the tag holds an invalid field number or wire
+// type.
+func (b *Buffer) TakeFieldTag() (fieldNum, wireType int) {
+	if b.err != nil {
+		return 0, 0
+	}
+	tag, n := binary.Uvarint(b.data[b.pos:])
+	if n <= 0 {
+		b.fail(fmt.Errorf("mbuff.Buffer.TakeFieldTag: malformed uvarint at pos %d: %w", b.pos, ErrOutOfRange))
+		return 0, 0
+	}
+	if tag>>3 > MaxFieldNum {
+		b.fail(fmt.Errorf("mbuff.Buffer.TakeFieldTag: field number %d at pos %d out of range [1, %d]", tag>>3, b.pos, MaxFieldNum))
+		return 0, 0
+	}
+	fieldNum, wireType = int(tag>>3), int(tag&7)
+	if err := checkFieldTag("Buffer.TakeFieldTag", fieldNum, wireType); err != nil {
+		b.fail(err)
+		return 0, 0
+	}
+	b.pos += n
+	return fieldNum, wireType
+}
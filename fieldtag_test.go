@@ -0,0 +1,48 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFieldTag tests writing and reading protobuf field tags.
+func TestFieldTag(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutFieldTag(1, WireVarint)
+	b.PutFieldTag(2, WireBytes)
+	b.PutFieldTag(16, WireFixed32)
+	b.PutFieldTag(MaxFieldNum, WireFixed64)
+	assert.Equal(t, []byte{0x08, 0x12, 0x85, 0x01, 0xF9, 0xFF, 0xFF, 0xFF, 0x0F}, b.Bytes())
+
+	b.Rewind()
+	for _, want := range [][2]int{{1, WireVarint}, {2, WireBytes}, {16, WireFixed32}, {MaxFieldNum, WireFixed64}} {
+		f, w := b.TakeFieldTag()
+		assert.Equal(t, want, [2]int{f, w})
+	}
+
+	// Invalid tags on write
+	assert.Panics(t, func() { b.PutFieldTag(0, WireVarint) })
+	assert.Panics(t, func() { b.PutFieldTag(MaxFieldNum+1, WireVarint) })
+	assert.Panics(t, func() { b.PutFieldTag(1, 6) })
+	assert.Panics(t, func() { b.PutFieldTag(1, -1) })
+
+	// Invalid tags on read leave the position unchanged
+	for _, in := range [][]byte{{0x80}, {0x07}, {0x06}, {0x80, 0x80, 0x80, 0x80, 0x20}} {
+		r := NewBufferFrom(in)
+		r.SetStrictMode(true)
+		f, w := r.TakeFieldTag()
+		assert.Equal(t, 0, f+w)
+		assert.Error(t, r.Err(), "in=%X", in)
+		assert.Equal(t, 0, r.Pos())
+	}
+	r := NewBufferFrom([]byte{0x80})
+	r.SetStrictMode(true)
+	r.TakeFieldTag()
+	assert.ErrorIs(t, r.Err(), ErrOutOfRange)
+}
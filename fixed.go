@@ -0,0 +1,79 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"fmt"
+	"math"
+)
+
+// fixedQWidth returns the total bit width of a signed Q(intBits.fracBits)
+// number, which is 1 sign bit plus intBits plus fracBits.
+// Panics unless the width is 8, 16, 32 or 64.
+func fixedQWidth(method string, intBits, fracBits int) int {
+	width := 1 + intBits + fracBits
+	if intBits < 0 || fracBits < 0 || (width != 8 && width != 16 && width != 32 && width != 64) {
+		panic(fmt.Sprintf("mbuff.%s: invalid Q%d.%d format", method, intBits, fracBits))
+	}
+	return width
+}
+
+// toFixedQ quantizes value to a signed Q(intBits.fracBits) integer of the
+// given width, rounding to nearest (half away from zero) and saturating to
+// the representable range. NaN is encoded as 0.
+func toFixedQ(value float64, fracBits, width int) int64 {
+	if math.IsNaN(value) {
+		return 0
+	}
+	r := math.Round(math.Ldexp(value, fracBits))
+	limit := math.Ldexp(1, width-1)
+	if r >= limit {
+		return int64(uint64(1)<<(width-1) - 1)
+	}
+	if r < -limit {
+		return -int64(uint64(1) << (width - 1))
+	}
+	return int64(r)
+}
+
+// PutFixedQ writes value as a signed Q(intBits.fracBits) fixed-point number,
+// i.e. round(value * 2^fracBits) stored as a two's complement integer of
+// 1+intBits+fracBits bits (8, 16, 32 or 64), then advances the position.
+// For example Q15 is PutFixedQ(v, 0, 15). Out-of-range values saturate.
+// Panics if the format is invalid or the write would exceed the buffer's capacity.
+func (b *Buffer) PutFixedQ(value float64, intBits, fracBits int) {
+	width := fixedQWidth("Buffer.PutFixedQ", intBits, fracBits)
+	raw := toFixedQ(value, fracBits, width)
+	switch width {
+	case 8:
+		b.PutU8(uint8(raw))
+	case 16:
+		b.PutU16(uint16(raw))
+	case 32:
+		b.PutU32(uint32(raw))
+	default:
+		b.PutU64(uint64(raw))
+	}
+}
+
+// TakeFixedQ reads a signed Q(intBits.fracBits) fixed-point number written by
+// PutFixedQ and returns its value, then advances the position.
+// Panics if the format is invalid.
+func (b *Buffer) TakeFixedQ(intBits, fracBits int) float64 {
+	width := fixedQWidth("Buffer.TakeFixedQ", intBits, fracBits)
+	var raw int64
+	switch width {
+	case 8:
+		raw = int64(int8(b.TakeU8()))
+	case 16:
+		raw = int64(int16(b.TakeU16()))
+	case 32:
+		raw = int64(int32(b.TakeU32()))
+	default:
+		raw = int64(b.TakeU64())
+	}
+	return math.Ldexp(float64(raw), -fracBits)
+}
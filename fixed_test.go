@@ -0,0 +1,64 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFixedQ tests fixed-point quantization, rounding and saturation.
+func TestFixedQ(t *testing.T) {
+	b := NewBuffer(64)
+
+	// Q15
+	b.PutFixedQ(0.5, 0, 15)
+	b.PutFixedQ(-1.0, 0, 15)
+	b.PutFixedQ(1.0, 0, 15)  // saturates to 0x7FFF
+	b.PutFixedQ(-2.0, 0, 15) // saturates to 0x8000
+	b.PutFixedQ(math.NaN(), 0, 15)
+	assert.Equal(t, []byte{0x40, 0x00, 0x80, 0x00, 0x7F, 0xFF, 0x80, 0x00, 0x00, 0x00}, b.Bytes())
+
+	b.Rewind()
+	assert.Equal(t, 0.5, b.TakeFixedQ(0, 15))
+	assert.Equal(t, -1.0, b.TakeFixedQ(0, 15))
+	assert.Equal(t, 32767.0/32768.0, b.TakeFixedQ(0, 15))
+	assert.Equal(t, -1.0, b.TakeFixedQ(0, 15))
+
+	// Rounding to nearest, half away from zero
+	b.Clear()
+	b.PutFixedQ(1.0/512, 0, 7)  // 0.25 LSB -> 0
+	b.PutFixedQ(3.0/256, 0, 7)  // 1.5 LSB -> 2
+	b.PutFixedQ(-3.0/256, 0, 7) // -1.5 LSB -> -2
+	assert.Equal(t, []byte{0x00, 0x02, 0xFE}, b.Bytes())
+
+	// Q7.8, Q31 and Q63
+	b.Clear()
+	b.PutFixedQ(-3.25, 7, 8)
+	b.PutFixedQ(0.25, 0, 31)
+	b.PutFixedQ(1e30, 0, 63)
+	b.Rewind()
+	assert.Equal(t, -3.25, b.TakeFixedQ(7, 8))
+	assert.Equal(t, 0.25, b.TakeFixedQ(0, 31))
+	assert.Equal(t, uint64(math.MaxInt64), b.PeekAbsU64(6))
+
+	// Invalid formats
+	assert.Panics(t, func() { b.PutFixedQ(0, 1, 1) })
+	assert.Panics(t, func() { b.TakeFixedQ(-1, 16) })
+}
+
+// TestBuilder_PutFixedQ tests that fixed-point writes grow the buffer.
+func TestBuilder_PutFixedQ(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutFixedQ(0.75, 0, 31)
+	b.PutFixedQ(-0.5, 3, 4)
+	assert.Equal(t, 5, b.Count())
+	b.Rewind()
+	assert.Equal(t, 0.75, b.TakeFixedQ(0, 31))
+	assert.Equal(t, -0.5, b.TakeFixedQ(3, 4))
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"math"
+)
+
+// PutF32 writes the IEEE-754 bits of a float32 at the current position and
+// advances the position. Byte order and high-low swap apply as for PutU32.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutF32(v float32) { b.PutU32(math.Float32bits(v)) }
+
+// PutF64 writes the IEEE-754 bits of a float64 at the current position and
+// advances the position. Byte order and high-low swap apply as for PutU64.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutF64(v float64) { b.PutU64(math.Float64bits(v)) }
+
+// TakeF32 reads a float32 at the current position, then advances the position.
+func (b *Buffer) TakeF32() float32 { return math.Float32frombits(b.TakeU32()) }
+
+// TakeF64 reads a float64 at the current position, then advances the position.
+func (b *Buffer) TakeF64() float64 { return math.Float64frombits(b.TakeU64()) }
+
+// PeekF32 reads a float32 at pos+offset without advancing the position.
+func (b *Buffer) PeekF32(offset int) float32 { return math.Float32frombits(b.PeekU32(offset)) }
+
+// PeekF64 reads a float64 at pos+offset without advancing the position.
+func (b *Buffer) PeekF64(offset int) float64 { return math.Float64frombits(b.PeekU64(offset)) }
+
+// OverwriteF32 overwrites a float32 at the specified offset.
+func (b *Buffer) OverwriteF32(offset int, v float32) { b.OverwriteU32(offset, math.Float32bits(v)) }
+
+// OverwriteF64 overwrites a float64 at the specified offset.
+func (b *Buffer) OverwriteF64(offset int, v float64) { b.OverwriteU64(offset, math.Float64bits(v)) }
+
+// PutArrF32 writes a float32 slice at the current position and advances the position.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutArrF32(v []float32) { b.PutArr32(reinterpret[uint32](v)) }
+
+// PutArrF64 writes a float64 slice at the current position and advances the position.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutArrF64(v []float64) { b.PutArr64(reinterpret[uint64](v)) }
+
+// TakeArrF32 reads float32 values at the current position into slice v, then advances the position.
+func (b *Buffer) TakeArrF32(v []float32) { b.TakeArr32(reinterpret[uint32](v)) }
+
+// TakeArrF64 reads float64 values at the current position into slice v, then advances the position.
+func (b *Buffer) TakeArrF64(v []float64) { b.TakeArr64(reinterpret[uint64](v)) }
+
+// PeekArrF32 reads float32 values at pos+offset into slice v without advancing the position.
+func (b *Buffer) PeekArrF32(offset int, v []float32) { b.PeekArr32(offset, reinterpret[uint32](v)) }
+
+// PeekArrF64 reads float64 values at pos+offset into slice v without advancing the position.
+func (b *Buffer) PeekArrF64(offset int, v []float64) { b.PeekArr64(offset, reinterpret[uint64](v)) }
@@ -0,0 +1,78 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFloat tests round-tripping float32 and float64 values.
+func TestFloat(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutF32(1.5)
+	b.PutF64(-2.25)
+	b.PutF32(float32(math.Inf(-1)))
+	b.PutF64(math.NaN())
+	assert.Equal(t, []byte{0x3F, 0xC0, 0x00, 0x00}, b.Bytes()[:4])
+	assert.Equal(t, []byte{0xC0, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, b.Bytes()[4:12])
+
+	b.Rewind()
+	assert.Equal(t, float32(1.5), b.PeekF32(0))
+	assert.Equal(t, -2.25, b.PeekF64(4))
+	assert.Equal(t, float32(1.5), b.TakeF32())
+	assert.Equal(t, -2.25, b.TakeF64())
+	assert.True(t, math.IsInf(float64(b.TakeF32()), -1))
+	assert.True(t, math.IsNaN(b.TakeF64()))
+
+	b.OverwriteF32(0, -0.5)
+	b.OverwriteF64(4, 3.0)
+	b.Rewind()
+	assert.Equal(t, float32(-0.5), b.TakeF32())
+	assert.Equal(t, 3.0, b.TakeF64())
+
+	// Byte order and high-low swap apply as for the integer methods
+	b.Clear()
+	b.SetEndian(LittleEndian)
+	b.SetHLSwap(true)
+	b.PutF64(1.0)
+	b.Rewind()
+	assert.Equal(t, math.Float64bits(1.0), b.TakeU64())
+
+	assert.Panics(t, func() { NewBuffer(3).PutF32(1) })
+	assert.Panics(t, func() { b.OverwriteF64(1, 1) })
+}
+
+// TestFloatArr tests bulk float arrays in both byte orders.
+func TestFloatArr(t *testing.T) {
+	f32 := []float32{1, -2.5, float32(math.MaxFloat32)}
+	f64 := []float64{math.Pi, -0.0, math.SmallestNonzeroFloat64}
+	for _, e := range []Endian{BigEndian, LittleEndian} {
+		b := NewBuilder(0)
+		b.SetEndian(e)
+		b.PutArrF32(f32)
+		b.PutArrF64(f64)
+		b.Rewind()
+		assert.Equal(t, math.Float32bits(f32[1]), b.PeekU32(4))
+
+		peek32 := make([]float32, 3)
+		b.PeekArrF32(0, peek32)
+		assert.Equal(t, f32, peek32)
+		peek64 := make([]float64, 3)
+		b.PeekArrF64(12, peek64)
+		assert.Equal(t, f64, peek64)
+
+		out32 := make([]float32, 3)
+		out64 := make([]float64, 3)
+		b.TakeArrF32(out32)
+		b.TakeArrF64(out64)
+		assert.Equal(t, f32, out32)
+		assert.Equal(t, f64, out64)
+		assert.True(t, b.AtEnd())
+	}
+}
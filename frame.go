@@ -0,0 +1,194 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"bytes"
+	"fmt"
+	"hash/adler32"
+	"hash/crc32"
+)
+
+// FrameComplete checks, without advancing the position, whether the readable
+// region holds a complete frame made of a prefixWidth-byte length prefix
+// (1, 2 or 4 bytes, in the buffer's byte order) followed by that many bytes.
+// frameLen is the total frame length including the prefix; it is 0 when
+// fewer than prefixWidth bytes are readable.
+// Panics if prefixWidth is invalid.
+func (b *Buffer) FrameComplete(prefixWidth int) (frameLen int, complete bool) {
+	if maxUintN(prefixWidth) == 0 {
+		panic(fmt.Sprintf("mbuff.Buffer.FrameComplete: invalid prefix width %d", prefixWidth))
+	}
+	if b.Readable() < prefixWidth {
+		return 0, false
+	}
+	frameLen = prefixWidth + int(b.uintN(b.pos, prefixWidth))
+	return frameLen, b.Readable() >= frameLen
+}
+
+// FrameChecksum selects the trailing checksum of a Frame.
+type FrameChecksum int
+
+// Frame checksum algorithms.
+const (
+	FrameNoChecksum FrameChecksum = iota // no checksum
+	FrameSum8                            // 8-bit additive sum
+	FrameSum16                           // 16-bit additive sum
+	FrameFletcher16                      // Fletcher-16
+	FrameFletcher32                      // Fletcher-32 over little-endian words
+	FrameAdler32                         // Adler-32
+	FrameCRC32                           // CRC-32 (IEEE)
+)
+
+// width returns the size of the checksum field, or -1 if c is unknown.
+func (c FrameChecksum) width() int {
+	switch c {
+	case FrameNoChecksum:
+		return 0
+	case FrameSum8:
+		return 1
+	case FrameSum16, FrameFletcher16:
+		return 2
+	case FrameFletcher32, FrameAdler32, FrameCRC32:
+		return 4
+	}
+	return -1
+}
+
+// sum computes the checksum of p.
+func (c FrameChecksum) sum(p []byte) uint32 {
+	switch c {
+	case FrameSum8:
+		return uint32(sum8(p))
+	case FrameSum16:
+		return uint32(sum16(p))
+	case FrameFletcher16:
+		return uint32(fletcher16(p))
+	case FrameFletcher32:
+		return fletcher32(p)
+	case FrameAdler32:
+		return adler32.Checksum(p)
+	case FrameCRC32:
+		return crc32.ChecksumIEEE(p)
+	}
+	return 0
+}
+
+// Frame describes a complete frame layout:
+//
+//	[magic] [version] [length] [payload] [checksum]
+//
+// The version, length and checksum are fields of the configured widths in the
+// buffer's byte order. The version field is omitted when VersionWidth is 0
+// and the checksum when Checksum is FrameNoChecksum. The checksum covers
+// everything from the start of the magic to the end of the payload.
+type Frame struct {
+	Magic        []byte        // leading magic bytes; may be empty
+	Version      uint32        // version written by Encode and required by Decode
+	VersionWidth int           // version field width: 0 (none), 1, 2 or 4
+	LengthWidth  int           // payload length field width: 1, 2 or 4
+	Checksum     FrameChecksum // trailing checksum algorithm
+}
+
+// headerLen validates the layout and returns the length of the fields
+// preceding the payload.
+func (f *Frame) headerLen(method string) (int, error) {
+	if f.VersionWidth != 0 && maxUintN(f.VersionWidth) == 0 {
+		return 0, fmt.Errorf("mbuff.%s: invalid version width %d", method, f.VersionWidth)
+	}
+	if maxUintN(f.LengthWidth) == 0 {
+		return 0, fmt.Errorf("mbuff.%s: invalid length width %d", method, f.LengthWidth)
+	}
+	if f.Checksum.width() < 0 {
+		return 0, fmt.Errorf("mbuff.%s: unknown checksum %d", method, f.Checksum)
+	}
+	if f.VersionWidth != 0 && uint64(f.Version) > maxUintN(f.VersionWidth) {
+		return 0, fmt.Errorf("mbuff.%s: version %d exceeds %d-byte field", method, f.Version, f.VersionWidth)
+	}
+	return len(f.Magic) + f.VersionWidth + f.LengthWidth, nil
+}
+
+// Encode writes payload as a complete frame at the current position of b and
+// advances the position. Returns an error, writing nothing, if the layout is
+// invalid or the payload is too long for the length field.
+func (f *Frame) Encode(b *Builder, payload []byte) error {
+	hdr, err := f.headerLen("Frame.Encode")
+	if err != nil {
+		return err
+	}
+	if uint64(len(payload)) > maxUintN(f.LengthWidth) {
+		return fmt.Errorf("mbuff.Frame.Encode: payload length %d exceeds %d-byte field", len(payload), f.LengthWidth)
+	}
+	csWidth := f.Checksum.width()
+	start := b.pos
+	end := start + hdr + len(payload)
+	required := end + csWidth
+	if !b.ensure(required) {
+		return b.err
+	}
+
+	// Extend data slice if needed
+	if required > len(b.data) {
+		b.data = b.data[:required]
+	}
+
+	p := start + copy(b.data[start:], f.Magic)
+	if f.VersionWidth != 0 {
+		b.putUintN(p, f.VersionWidth, f.Version)
+		p += f.VersionWidth
+	}
+	b.putUintN(p, f.LengthWidth, uint32(len(payload)))
+	copy(b.data[p+f.LengthWidth:], payload)
+	if csWidth != 0 {
+		b.putUintN(end, csWidth, f.Checksum.sum(b.data[start:end]))
+	}
+	b.pos = required
+	return nil
+}
+
+// Decode reads a complete frame at the current position of b, validating the
+// magic, version and checksum, and returns a copy of its payload. The
+// position is advanced past the frame only on success. An incomplete frame
+// yields an error wrapping ErrOutOfRange, so a stream reader can wait for
+// more data; a checksum mismatch yields an error wrapping ErrChecksum.
+func (f *Frame) Decode(b *Buffer) ([]byte, error) {
+	hdr, err := f.headerLen("Frame.Decode")
+	if err != nil {
+		return nil, err
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	start := b.pos
+	if b.Readable() < hdr {
+		return nil, fmt.Errorf("mbuff.Frame.Decode: incomplete header at pos %d: %w", start, ErrOutOfRange)
+	}
+	p := start + len(f.Magic)
+	if !bytes.Equal(b.data[start:p], f.Magic) {
+		return nil, fmt.Errorf("mbuff.Frame.Decode: bad magic % X at pos %d", b.data[start:p], start)
+	}
+	if f.VersionWidth != 0 {
+		if v := b.uintN(p, f.VersionWidth); v != f.Version {
+			return nil, fmt.Errorf("mbuff.Frame.Decode: version %d at pos %d, want %d", v, p, f.Version)
+		}
+		p += f.VersionWidth
+	}
+	n := int(b.uintN(p, f.LengthWidth))
+	p += f.LengthWidth
+	csWidth := f.Checksum.width()
+	if b.Readable() < hdr+n+csWidth {
+		return nil, fmt.Errorf("mbuff.Frame.Decode: incomplete frame of %d bytes at pos %d: %w", hdr+n+csWidth, start, ErrOutOfRange)
+	}
+	end := p + n
+	if csWidth != 0 {
+		if stored, sum := b.uintN(end, csWidth), f.Checksum.sum(b.data[start:end]); stored != sum {
+			return nil, fmt.Errorf("mbuff.Frame.Decode: stored 0x%X, computed 0x%X at pos %d: %w", stored, sum, start, ErrChecksum)
+		}
+	}
+	payload := bytes.Clone(b.data[p:end])
+	b.pos = end + csWidth
+	return payload, nil
+}
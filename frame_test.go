@@ -0,0 +1,156 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFrameComplete tests detecting complete length-prefixed frames.
+func TestFrameComplete(t *testing.T) {
+	b := NewBuffer(16)
+
+	// Not enough for the prefix
+	b.PutU8(0x00)
+	b.Rewind()
+	n, ok := b.FrameComplete(2)
+	assert.False(t, ok)
+	assert.Equal(t, 0, n)
+
+	// Prefix present, payload incomplete
+	b.Seek(1)
+	b.PutU8(0x03)
+	b.PutU16(0xAABB)
+	b.Rewind()
+	n, ok = b.FrameComplete(2)
+	assert.False(t, ok)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, 0, b.Pos())
+
+	// Complete frame, followed by more data
+	b.Seek(4)
+	b.PutU8(0xCC)
+	b.PutU8(0xDD)
+	b.Rewind()
+	n, ok = b.FrameComplete(2)
+	assert.True(t, ok)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, 0, b.Pos())
+
+	// Relative to pos and in buffer byte order
+	b.Clear()
+	b.SetEndian(LittleEndian)
+	b.PutU8(0xFF)
+	b.PutU32(1)
+	b.PutU8(0xEE)
+	b.Seek(1)
+	n, ok = b.FrameComplete(4)
+	assert.True(t, ok)
+	assert.Equal(t, 5, n)
+
+	n, ok = b.FrameComplete(1)
+	assert.True(t, ok)
+	assert.Equal(t, 2, n)
+
+	assert.Panics(t, func() { b.FrameComplete(3) })
+}
+
+// TestFrame tests encoding and decoding complete frames.
+func TestFrame(t *testing.T) {
+	f := &Frame{
+		Magic:        []byte{0xAA, 0x55},
+		Version:      3,
+		VersionWidth: 1,
+		LengthWidth:  2,
+		Checksum:     FrameSum8,
+	}
+
+	b := NewBuilder(0)
+	assert.NoError(t, f.Encode(b, []byte{0x01, 0x02}))
+	assert.NoError(t, f.Encode(b, nil))
+	expected := []byte{
+		0xAA, 0x55, 0x03, 0x00, 0x02, 0x01, 0x02, 0x07,
+		0xAA, 0x55, 0x03, 0x00, 0x00, 0x02,
+	}
+	assert.Equal(t, expected, b.Bytes())
+
+	b.Rewind()
+	payload, err := f.Decode(&b.Buffer)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x01, 0x02}, payload)
+	payload, err = f.Decode(&b.Buffer)
+	assert.NoError(t, err)
+	assert.Empty(t, payload)
+	assert.Equal(t, 0, b.Readable())
+}
+
+// TestFrame_Checksums tests round trips with every checksum algorithm.
+func TestFrame_Checksums(t *testing.T) {
+	checksums := []FrameChecksum{
+		FrameNoChecksum, FrameSum8, FrameSum16, FrameFletcher16,
+		FrameFletcher32, FrameAdler32, FrameCRC32,
+	}
+	for _, cs := range checksums {
+		f := &Frame{Magic: []byte("MB"), LengthWidth: 4, Checksum: cs}
+		b := NewBuilder(0)
+		b.SetEndian(LittleEndian)
+		assert.NoError(t, f.Encode(b, []byte("hello")))
+		assert.Equal(t, 2+4+5+cs.width(), b.Count())
+
+		b.Rewind()
+		payload, err := f.Decode(&b.Buffer)
+		assert.NoError(t, err, "checksum %d", cs)
+		assert.Equal(t, []byte("hello"), payload)
+	}
+
+	// Standard check values
+	assert.Equal(t, uint32(0xCBF43926), FrameCRC32.sum([]byte("123456789")))
+	assert.Equal(t, uint32(0x091E01DE), FrameAdler32.sum([]byte("123456789")))
+}
+
+// TestFrame_Invalid tests decoding errors and invalid layouts.
+func TestFrame_Invalid(t *testing.T) {
+	f := &Frame{Magic: []byte{0x7E}, Version: 1, VersionWidth: 1, LengthWidth: 1, Checksum: FrameSum16}
+	b := NewBuilder(0)
+	assert.NoError(t, f.Encode(b, []byte{0x10, 0x20, 0x30}))
+	frame := append([]byte(nil), b.Bytes()...)
+
+	// Incomplete input at every length
+	for n := 0; n < len(frame); n++ {
+		_, err := f.Decode(NewBufferFrom(frame[:n]))
+		assert.ErrorIs(t, err, ErrOutOfRange, "length %d", n)
+	}
+
+	// Corrupted payload
+	bad := append([]byte(nil), frame...)
+	bad[4] ^= 0x01
+	r := NewBufferFrom(bad)
+	_, err := f.Decode(r)
+	assert.ErrorIs(t, err, ErrChecksum)
+	assert.Equal(t, 0, r.Pos())
+
+	// Bad magic and version
+	bad = append([]byte(nil), frame...)
+	bad[0] = 0x00
+	_, err = f.Decode(NewBufferFrom(bad))
+	assert.ErrorContains(t, err, "bad magic")
+	f2 := *f
+	f2.Version = 2
+	_, err = f2.Decode(NewBufferFrom(frame))
+	assert.ErrorContains(t, err, "version 1")
+
+	// Invalid layouts and oversized fields
+	assert.Error(t, (&Frame{LengthWidth: 3}).Encode(b, nil))
+	assert.Error(t, (&Frame{LengthWidth: 1, VersionWidth: 8}).Encode(b, nil))
+	assert.Error(t, (&Frame{LengthWidth: 1, Checksum: 99}).Encode(b, nil))
+	assert.Error(t, (&Frame{LengthWidth: 1, VersionWidth: 1, Version: 256}).Encode(b, nil))
+	assert.Error(t, (&Frame{LengthWidth: 1}).Encode(b, make([]byte, 256)))
+	_, err = (&Frame{LengthWidth: 0}).Decode(NewBufferFrom(frame))
+	assert.Error(t, err)
+	assert.Equal(t, len(frame), b.Count())
+}
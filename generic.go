@@ -0,0 +1,101 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// Integer is the set of fixed-width integer types.
+type Integer interface {
+	~int8 | ~int16 | ~int32 | ~int64 | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// ArrayPutter is implemented by Buffer and Builder.
+type ArrayPutter interface {
+	PutArr8(v []byte)
+	PutArr16(v []uint16)
+	PutArr32(v []uint32)
+	PutArr64(v []uint64)
+}
+
+// ArrayTaker is implemented by Buffer and Builder.
+type ArrayTaker interface {
+	TakeArr8(v []byte)
+	TakeArr16(v []uint16)
+	TakeArr32(v []uint32)
+	TakeArr64(v []uint64)
+}
+
+// reinterpret returns the memory of v as a slice of U, which must have the same size as T.
+func reinterpret[U, T any](v []T) []U {
+	return unsafe.Slice((*U)(unsafe.Pointer(unsafe.SliceData(v))), len(v))
+}
+
+// PutIntArr writes an integer slice of any fixed-width element type through
+// the matching PutArr method of w, honoring its byte order and high-low swap.
+// Signed values are written as their two's complement bit pattern.
+func PutIntArr[T Integer](w ArrayPutter, v []T) {
+	var zero T
+	switch unsafe.Sizeof(zero) {
+	case 1:
+		w.PutArr8(reinterpret[uint8](v))
+	case 2:
+		w.PutArr16(reinterpret[uint16](v))
+	case 4:
+		w.PutArr32(reinterpret[uint32](v))
+	default:
+		w.PutArr64(reinterpret[uint64](v))
+	}
+}
+
+// TakeIntArr reads an integer slice of any fixed-width element type through
+// the matching TakeArr method of r, honoring its byte order and high-low swap.
+func TakeIntArr[T Integer](r ArrayTaker, v []T) {
+	var zero T
+	switch unsafe.Sizeof(zero) {
+	case 1:
+		r.TakeArr8(reinterpret[uint8](v))
+	case 2:
+		r.TakeArr16(reinterpret[uint16](v))
+	case 4:
+		r.TakeArr32(reinterpret[uint32](v))
+	default:
+		r.TakeArr64(reinterpret[uint64](v))
+	}
+}
+
+// DecodeRecords fills out by calling decode once per element, in order, with
+// b positioned at the start of each record. It stops at the first failure,
+// returning an error that names the record index and wraps the cause:
+// decode's error, the error it recorded in error mode, or ErrOutOfRange if b
+// has no readable data left before out is full. The position is then
+// restored to the start of the failed record, and earlier records stay
+// decoded and consumed. Outside error mode, a truncated record panics as the
+// Take methods do.
+func DecodeRecords[T any](b *Buffer, out []T, decode func(*Buffer, *T) error) error {
+	for i := range out {
+		start := b.pos
+		err := b.err
+		if err == nil && b.AtEnd() {
+			err = ErrOutOfRange
+		}
+		if err == nil {
+			err = decode(b, &out[i])
+		}
+		if err == nil {
+			err = b.err
+		}
+		if err != nil {
+			if start <= len(b.data) {
+				b.setPos(start)
+			}
+			return fmt.Errorf("mbuff.DecodeRecords: record %d of %d at pos %d: %w", i, len(out), start, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testIntArrRoundTrip[T Integer](t *testing.T, in []T, enc []byte) {
+	t.Helper()
+	b := NewBuilder(0)
+	PutIntArr(b, in)
+	assert.Equal(t, enc, b.Bytes())
+
+	out := make([]T, len(in))
+	b.Rewind()
+	TakeIntArr(b, out)
+	assert.Equal(t, in, out)
+	assert.Equal(t, 0, b.Readable())
+}
+
+// TestIntArr tests generic array writes across all element types.
+func TestIntArr(t *testing.T) {
+	testIntArrRoundTrip(t, []uint8{0x01, 0xFF}, []byte{0x01, 0xFF})
+	testIntArrRoundTrip(t, []int8{1, -1}, []byte{0x01, 0xFF})
+	testIntArrRoundTrip(t, []uint16{0x0102}, []byte{0x01, 0x02})
+	testIntArrRoundTrip(t, []int16{-2}, []byte{0xFF, 0xFE})
+	testIntArrRoundTrip(t, []uint32{0x01020304}, []byte{0x01, 0x02, 0x03, 0x04})
+	testIntArrRoundTrip(t, []int32{-3}, []byte{0xFF, 0xFF, 0xFF, 0xFD})
+	testIntArrRoundTrip(t, []uint64{0x0102030405060708}, []byte{1, 2, 3, 4, 5, 6, 7, 8})
+	testIntArrRoundTrip(t, []int64{-4}, []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFC})
+
+	// Named types, byte order and high-low swap
+	type sample int32
+	b := NewBuffer(16)
+	b.SetEndian(LittleEndian)
+	b.SetHLSwap(true)
+	PutIntArr(b, []sample{-2})
+	assert.Equal(t, []byte{0xFF, 0xFE, 0xFF, 0xFF}, b.Bytes())
+	out := make([]sample, 1)
+	b.Rewind()
+	TakeIntArr(b, out)
+	assert.Equal(t, []sample{-2}, out)
+
+	// Bounds checks come from the underlying methods
+	assert.Panics(t, func() { PutIntArr(b, make([]int64, 2)) })
+}
+
+// TestDecodeRecords tests bulk decoding of fixed-layout records.
+func TestDecodeRecords(t *testing.T) {
+	type point struct {
+		X uint16
+		Y uint8
+	}
+	decode := func(b *Buffer, p *point) error {
+		p.X = b.TakeU16()
+		p.Y = b.TakeU8()
+		if p.Y == 0xFF {
+			return errors.New("bad y")
+		}
+		return nil
+	}
+
+	b := NewBufferFrom([]byte{0, 1, 2, 0, 3, 4, 0, 5, 0xFF, 0, 6})
+	out := make([]point, 2)
+	assert.NoError(t, DecodeRecords(b, out, decode))
+	assert.Equal(t, []point{{1, 2}, {3, 4}}, out)
+	assert.Equal(t, 6, b.Pos())
+
+	// decode errors restore the position to the failed record
+	err := DecodeRecords(b, out, decode)
+	assert.EqualError(t, err, "mbuff.DecodeRecords: record 0 of 2 at pos 6: bad y")
+	assert.Equal(t, 6, b.Pos())
+
+	// Running out of data, at a boundary or mid-record
+	b.Skip(5)
+	err = DecodeRecords(b, out, decode)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	assert.Equal(t, 11, b.Pos())
+
+	b.SetStrictMode(true)
+	b.Seek(0)
+	out = make([]point, 4)
+	err = DecodeRecords(b, out, decode)
+	assert.ErrorContains(t, err, "record 2 of 4 at pos 6")
+	assert.Equal(t, 6, b.Pos())
+	b.Seek(9)
+	err = DecodeRecords(b, out[:1], decode)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	assert.Equal(t, 9, b.Pos())
+
+	assert.NoError(t, DecodeRecords(NewBuffer(0), []point{}, decode))
+}
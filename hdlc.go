@@ -0,0 +1,93 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// HDLC framing bytes.
+const (
+	hdlcFlag   = 0x7E // frame delimiter
+	hdlcEscape = 0x7D // escape byte; the next byte is XORed with hdlcXor
+	hdlcXor    = 0x20
+)
+
+// hdlcLen returns the length of payload as an HDLC frame, including both flags.
+func hdlcLen(payload []byte) int {
+	n := len(payload) + 2
+	for _, c := range payload {
+		if c == hdlcFlag || c == hdlcEscape {
+			n++
+		}
+	}
+	return n
+}
+
+// EncodeHDLC writes payload as an HDLC-style frame at the current position
+// and advances the position. The frame is delimited by 0x7E flags, and any
+// 0x7E or 0x7D in the payload is replaced by 0x7D followed by the byte
+// XORed with 0x20.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) EncodeHDLC(payload []byte) {
+	n := hdlcLen(payload)
+	required := b.pos + n
+	if !b.checkWritable("EncodeHDLC", required) {
+		return
+	}
+
+	p := b.pos
+	b.data[p] = hdlcFlag
+	p++
+	for _, c := range payload {
+		if c == hdlcFlag || c == hdlcEscape {
+			b.data[p] = hdlcEscape
+			p++
+			c ^= hdlcXor
+		}
+		b.data[p] = c
+		p++
+	}
+	b.data[p] = hdlcFlag
+	b.pos = required
+}
+
+// DecodeHDLC reads a frame written by EncodeHDLC, from its opening flag at the
+// current position up to and including the closing flag, and returns the
+// unstuffed payload in a new slice. Each frame must start with its own
+// opening flag. Returns an error, without advancing, if there is no opening
+// flag, the frame is unterminated, or an escape byte directly precedes the
+// closing flag.
+func (b *Buffer) DecodeHDLC() ([]byte, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.Readable() == 0 || b.data[b.pos] != hdlcFlag {
+		return nil, fmt.Errorf("mbuff.Buffer.DecodeHDLC: missing opening flag at pos %d", b.pos)
+	}
+	body := b.data[b.pos+1:]
+	end := bytes.IndexByte(body, hdlcFlag)
+	if end < 0 {
+		return nil, fmt.Errorf("mbuff.Buffer.DecodeHDLC: unterminated frame at pos %d", b.pos)
+	}
+	body = body[:end]
+
+	payload := make([]byte, 0, len(body))
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		if c == hdlcEscape {
+			i++
+			if i == len(body) {
+				return nil, fmt.Errorf("mbuff.Buffer.DecodeHDLC: escape at end of frame at pos %d", b.pos)
+			}
+			c = body[i] ^ hdlcXor
+		}
+		payload = append(payload, c)
+	}
+	b.pos += end + 2
+	return payload, nil
+}
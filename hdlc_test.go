@@ -0,0 +1,66 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHDLC tests HDLC byte stuffing round trips.
+func TestHDLC(t *testing.T) {
+	payloads := [][]byte{
+		{0x01, 0x02},
+		{0x7E},
+		{0x7D},
+		{},
+		{0x7D, 0x7E, 0x5D, 0x5E, 0x7D},
+	}
+
+	b := NewBuilder(0)
+	for _, p := range payloads {
+		b.EncodeHDLC(p)
+	}
+	expected := []byte{
+		0x7E, 0x01, 0x02, 0x7E,
+		0x7E, 0x7D, 0x5E, 0x7E,
+		0x7E, 0x7D, 0x5D, 0x7E,
+		0x7E, 0x7E,
+		0x7E, 0x7D, 0x5D, 0x7D, 0x5E, 0x5D, 0x5E, 0x7D, 0x5D, 0x7E,
+	}
+	assert.Equal(t, expected, b.Bytes())
+
+	b.Rewind()
+	for _, p := range payloads {
+		v, err := b.DecodeHDLC()
+		assert.NoError(t, err)
+		assert.Equal(t, p, v)
+	}
+	assert.Equal(t, 0, b.Readable())
+
+	fixed := NewBuffer(3)
+	assert.Panics(t, func() { fixed.EncodeHDLC([]byte{0x7E}) })
+}
+
+// TestHDLC_Malformed tests decoding of malformed frames.
+func TestHDLC_Malformed(t *testing.T) {
+	cases := []struct {
+		data []byte
+		msg  string
+	}{
+		{[]byte{}, "missing opening flag"},
+		{[]byte{0x01, 0x7E}, "missing opening flag"},
+		{[]byte{0x7E, 0x01, 0x02}, "unterminated frame"},
+		{[]byte{0x7E, 0x01, 0x7D, 0x7E}, "escape at end of frame"},
+	}
+	for _, c := range cases {
+		b := NewBufferFrom(c.data)
+		_, err := b.DecodeHDLC()
+		assert.ErrorContains(t, err, c.msg)
+		assert.Equal(t, 0, b.Pos())
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// headerField is one scalar of a flattened header layout.
+type headerField struct {
+	index []int            // struct field and array element indices leading to the scalar
+	path  string           // field path for errors, e.g. Header.Flags[1]
+	width int              // encoded width in bytes
+	order binary.ByteOrder // byte order, or nil for the buffer's
+}
+
+// headerLayout is the flattened, cached layout of a header struct type.
+type headerLayout struct {
+	fields []headerField
+	size   int
+	err    error
+}
+
+// headerLayouts caches a *headerLayout per reflect.Type.
+var headerLayouts sync.Map
+
+// layoutOf returns the cached layout of the struct type t, computing it on
+// first use.
+func layoutOf(t reflect.Type) *headerLayout {
+	if l, ok := headerLayouts.Load(t); ok {
+		return l.(*headerLayout)
+	}
+	l := &headerLayout{}
+	if t.Kind() != reflect.Struct {
+		l.err = fmt.Errorf("expected struct, got %s", t)
+	} else {
+		l.err = l.flatten(t, nil, fieldCodec{}, t.Name())
+	}
+	actual, _ := headerLayouts.LoadOrStore(t, l)
+	return actual.(*headerLayout)
+}
+
+// flatten appends the scalars of t, reached through index, to the layout,
+// following the same rules as Unmarshal.
+func (l *headerLayout) flatten(t reflect.Type, index []int, c fieldCodec, path string) error {
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			fc, skip, err := parseFieldTag(f.Tag.Get("mbuff"))
+			if err != nil {
+				return fmt.Errorf("%s.%s: %w", path, f.Name, err)
+			}
+			if skip {
+				continue
+			}
+			if err := l.flatten(f.Type, append(index[:len(index):len(index)], i), fc, path+"."+f.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Array:
+		for i := 0; i < t.Len(); i++ {
+			if err := l.flatten(t.Elem(), append(index[:len(index):len(index)], i), c, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	width, err := codecWidth(t.Kind(), c, path)
+	if err != nil {
+		return err
+	}
+	l.fields = append(l.fields, headerField{index: index, path: path, width: width, order: c.order})
+	l.size += width
+	return nil
+}
+
+// DecodeHeader reads a T, which must be a struct, from b with the same field
+// rules as Unmarshal and returns it, then advances the position. The layout
+// of T is reflected once and cached, so repeated calls avoid walking the type.
+// The whole header is bounds-checked up front, so the position is left
+// unchanged if it is truncated. A decoded value that overflows its field's
+// kind returns an error naming the field, also without advancing.
+func DecodeHeader[T any](b *Buffer) (T, error) {
+	var h T
+	l := layoutOf(reflect.TypeOf((*T)(nil)).Elem())
+	if l.err != nil {
+		return h, fmt.Errorf("mbuff.DecodeHeader: %w", l.err)
+	}
+	if !b.checkReadable(l.size) {
+		return h, b.err
+	}
+
+	root := reflect.ValueOf(&h).Elem()
+	readPos := b.pos
+	for _, f := range l.fields {
+		v := root
+		for _, i := range f.index {
+			if v.Kind() == reflect.Array {
+				v = v.Index(i)
+			} else {
+				v = v.Field(i)
+			}
+		}
+		if err := setScalar(v, b.decodeScalar(b.data[readPos:readPos+f.width], f.order), f.width, f.path); err != nil {
+			return h, fmt.Errorf("mbuff.DecodeHeader: %w", err)
+		}
+		readPos += f.width
+	}
+	b.pos = readPos
+	return h, nil
+}
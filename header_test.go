@@ -0,0 +1,70 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDecodeHeader tests typed header decoding with a cached layout.
+func TestDecodeHeader(t *testing.T) {
+	in := testMessage{
+		Header:  testHeader{Version: 1, Flags: 0x0203, Length: 0x04050607},
+		Offset:  -2,
+		Valid:   true,
+		Scale:   1.5,
+		Samples: [3]uint16{0x0A0B, 0x0C0D, 0x0E0F},
+	}
+	b := NewBuilder(0)
+	assert.NoError(t, b.Marshal(&in))
+	assert.NoError(t, b.Marshal(&in))
+	b.Rewind()
+
+	for i := 0; i < 2; i++ {
+		out, err := DecodeHeader[testMessage](&b.Buffer)
+		assert.NoError(t, err)
+		assert.Equal(t, in, out)
+	}
+	assert.Equal(t, 0, b.Readable())
+
+	l := layoutOf(reflect.TypeOf(testMessage{}))
+	assert.Equal(t, 20, l.size)
+	assert.Len(t, l.fields, 9)
+	assert.Same(t, l, layoutOf(reflect.TypeOf(testMessage{})))
+}
+
+// TestDecodeHeader_Errors tests truncated input and unsupported types.
+func TestDecodeHeader_Errors(t *testing.T) {
+	b := NewBufferFrom([]byte{0x01, 0x02, 0x03})
+	b.SetStrictMode(true)
+	_, err := DecodeHeader[testHeader](b)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	assert.Equal(t, 0, b.Pos())
+
+	type bad struct {
+		Name string
+	}
+	_, err = DecodeHeader[bad](NewBuffer(0))
+	assert.ErrorContains(t, err, "unsupported kind string")
+	_, err = DecodeHeader[uint32](NewBuffer(0))
+	assert.ErrorContains(t, err, "expected struct")
+	_, err = DecodeHeader[any](NewBuffer(0))
+	assert.ErrorContains(t, err, "expected struct, got interface {}")
+	assert.Panics(t, func() { _, _ = DecodeHeader[testHeader](NewBuffer(0)) })
+
+	// Decoded values must fit the field kind
+	type narrow struct {
+		Pad [2]uint8
+		Len [2]uint16 `mbuff:"u32"`
+	}
+	b = NewBufferFrom([]byte{0, 0, 0, 0, 0xFF, 0xFF, 0, 1, 0, 0})
+	_, err = DecodeHeader[narrow](b)
+	assert.ErrorContains(t, err, "narrow.Len[1]: decoded value 65536 overflows uint16")
+	assert.Equal(t, 0, b.Pos())
+}
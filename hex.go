@@ -0,0 +1,153 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// fromHexChar converts a hex character into its value.
+func fromHexChar(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
+
+// isHexSpace reports whether c is whitespace that may separate hex digits.
+func isHexSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// putHex validates s, then decodes it directly into the buffer.
+// Nothing is written if s is malformed.
+func (b *Builder) putHex(method string, s string, skipSpace bool) error {
+	digits := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if skipSpace && isHexSpace(c) {
+			continue
+		}
+		if _, ok := fromHexChar(c); !ok {
+			return fmt.Errorf("mbuff.Builder.%s: invalid hex character %q at index %d", method, c, i)
+		}
+		digits++
+	}
+	if digits%2 != 0 {
+		return fmt.Errorf("mbuff.Builder.%s: odd number of hex digits %d", method, digits)
+	}
+
+	byteLen := digits >> 1
+	required := b.pos + byteLen
+	if !b.ensure(required) {
+		return b.err
+	}
+
+	// Extend data slice if needed
+	if required > len(b.data) {
+		b.data = b.data[:required]
+	}
+
+	writePos := b.pos
+	high, half := byte(0), false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if skipSpace && isHexSpace(c) {
+			continue
+		}
+		v, _ := fromHexChar(c)
+		if !half {
+			high, half = v, true
+			continue
+		}
+		b.data[writePos] = high<<4 | v
+		writePos++
+		half = false
+	}
+	b.pos += byteLen
+	return nil
+}
+
+// PutHexString hex-decodes s and writes the bytes at the current position,
+// then advances the position. The buffer will automatically grow if necessary.
+// Returns an error, without writing anything, if s has an odd length or
+// contains a non-hex character.
+func (b *Builder) PutHexString(s string) error {
+	return b.putHex("PutHexString", s, false)
+}
+
+// PutHexDump is like PutHexString but ignores spaces, tabs and line breaks,
+// so multi-line hex dumps can be pasted as-is.
+func (b *Builder) PutHexDump(s string) error {
+	return b.putHex("PutHexDump", s, true)
+}
+
+// ReadableHex returns the readable region [pos:len] as a lowercase hex
+// string, e.g. to log the undecoded remainder of a frame.
+func (b *Buffer) ReadableHex() string { return hex.EncodeToString(b.ReadableBytes()) }
+
+// BytesHex returns the valid data [0:len] as a lowercase hex string.
+func (b *Buffer) BytesHex() string { return hex.EncodeToString(b.data) }
+
+// Dump returns the valid data [0:len] rendered like `hexdump -C`: each line
+// holds an offset, 16 hex bytes and an ASCII gutter. The separator before
+// the byte at the current position is replaced by '>'. If limit is positive,
+// only the first limit bytes are rendered and a line counts the rest. The
+// last line holds the data length, followed by the position if no row
+// showed it.
+func (b *Buffer) Dump(limit int) string {
+	n := len(b.data)
+	if limit > 0 && n > limit {
+		n = limit
+	}
+
+	var sb strings.Builder
+	marked := false
+	for row := 0; row < n; row += 16 {
+		end := min(row+16, n)
+		fmt.Fprintf(&sb, "%08x ", row)
+		for i := row; i < row+16; i++ {
+			if i-row == 8 {
+				sb.WriteByte(' ')
+			}
+			if i == b.pos {
+				sb.WriteByte('>')
+				marked = true
+			} else {
+				sb.WriteByte(' ')
+			}
+			if i < end {
+				fmt.Fprintf(&sb, "%02x", b.data[i])
+			} else {
+				sb.WriteString("  ")
+			}
+		}
+		sb.WriteString("  |")
+		for _, c := range b.data[row:end] {
+			if c < 0x20 || c > 0x7E {
+				c = '.'
+			}
+			sb.WriteByte(c)
+		}
+		sb.WriteString("|\n")
+	}
+	if n < len(b.data) {
+		fmt.Fprintf(&sb, "... %d more bytes\n", len(b.data)-n)
+	}
+	fmt.Fprintf(&sb, "%08x", len(b.data))
+	if !marked {
+		fmt.Fprintf(&sb, "  (pos %08x)", b.pos)
+	}
+	sb.WriteByte('\n')
+	return sb.String()
+}
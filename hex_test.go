@@ -0,0 +1,78 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBuilder_PutHexString tests writing hex-decoded strings.
+func TestBuilder_PutHexString(t *testing.T) {
+	b := NewBuilder(0)
+	assert.NoError(t, b.PutHexString("01ff02"))
+	assert.NoError(t, b.PutHexString("DeadBeef"))
+	assert.NoError(t, b.PutHexString(""))
+	assert.Equal(t, []byte{0x01, 0xFF, 0x02, 0xDE, 0xAD, 0xBE, 0xEF}, b.Bytes())
+	assert.Equal(t, 7, b.Pos())
+
+	// Errors leave the buffer untouched
+	assert.Error(t, b.PutHexString("abc"))
+	assert.Error(t, b.PutHexString("zz"))
+	assert.Error(t, b.PutHexString("01 02"))
+	assert.Equal(t, 7, b.Count())
+	assert.Equal(t, 7, b.Pos())
+}
+
+// TestBuilder_PutHexDump tests writing whitespace-separated hex dumps.
+func TestBuilder_PutHexDump(t *testing.T) {
+	b := NewBuilder(0)
+	assert.NoError(t, b.PutHexDump("01 02 03 04\n\t05 06\r\n0 7"))
+	assert.Equal(t, []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07}, b.Bytes())
+
+	assert.Error(t, b.PutHexDump("01 0"))
+	assert.Error(t, b.PutHexDump("01 0g"))
+	assert.Equal(t, 7, b.Count())
+}
+
+// TestReadableHex tests the hex string helpers.
+func TestReadableHex(t *testing.T) {
+	b := NewBufferFrom([]byte{0x01, 0xAB, 0xFF})
+	assert.Equal(t, "01abff", b.BytesHex())
+	assert.Equal(t, "01abff", b.ReadableHex())
+	b.Skip(2)
+	assert.Equal(t, "ff", b.ReadableHex())
+	b.Skip(1)
+	assert.Equal(t, "", b.ReadableHex())
+	assert.Equal(t, "01abff", b.BytesHex())
+	assert.Equal(t, "", NewBuffer(4).BytesHex())
+}
+
+// TestDump tests the hexdump-style rendering.
+func TestDump(t *testing.T) {
+	b := NewBufferFrom([]byte("Hello, mbuff!\x00\x01\x02\xffabc"))
+	b.Seek(2)
+	assert.Equal(t, ""+
+		"00000000  48 65>6c 6c 6f 2c 20 6d  62 75 66 66 21 00 01 02  |Hello, mbuff!...|\n"+
+		"00000010  ff 61 62 63                                       |.abc|\n"+
+		"00000014\n", b.Dump(0))
+
+	// The position after the last byte of a partial row
+	b.Seek(b.Count())
+	assert.Equal(t, ""+
+		"00000000  48 65 6c 6c 6f 2c 20 6d  62 75 66 66 21 00 01 02  |Hello, mbuff!...|\n"+
+		"00000010  ff 61 62 63>                                      |.abc|\n"+
+		"00000014\n", b.Dump(0))
+
+	// Truncated output names the position on the last line
+	assert.Equal(t, ""+
+		"00000000  48 65 6c 6c                                       |Hell|\n"+
+		"... 16 more bytes\n"+
+		"00000014  (pos 00000014)\n", b.Dump(4))
+
+	assert.Equal(t, "00000000  (pos 00000000)\n", NewBuffer(4).Dump(0))
+}
@@ -11,9 +11,7 @@ func (b *Buffer) HLSwap32(v uint32) uint32 {
 	if !b.hlswap {
 		return v
 	}
-	part1 := (v & 0xFF00FF00) >> 8
-	part2 := (v & 0x00FF00FF) << 8
-	return part1 | part2
+	return SwapHL32(v)
 }
 
 // HLSwap64 swaps high and low bytes within each 16-bit word of a uint64.
@@ -22,6 +20,20 @@ func (b *Buffer) HLSwap64(v uint64) uint64 {
 	if !b.hlswap {
 		return v
 	}
+	return SwapHL64(v)
+}
+
+// SwapHL32 unconditionally swaps high and low bytes within each 16-bit word
+// of a uint32, as Buffer.HLSwap32 does when high-low swap is enabled.
+func SwapHL32(v uint32) uint32 {
+	part1 := (v & 0xFF00FF00) >> 8
+	part2 := (v & 0x00FF00FF) << 8
+	return part1 | part2
+}
+
+// SwapHL64 unconditionally swaps high and low bytes within each 16-bit word
+// of a uint64, as Buffer.HLSwap64 does when high-low swap is enabled.
+func SwapHL64(v uint64) uint64 {
 	part1 := (v & 0xFF00FF00FF00FF00) >> 8
 	part2 := (v & 0x00FF00FF00FF00FF) << 8
 	return part1 | part2
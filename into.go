@@ -0,0 +1,80 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"encoding/binary"
+)
+
+// The functions in this file encode directly into a caller-managed []byte,
+// for hot paths that cannot afford a Buffer per message. Each returns the
+// offset just past the value. Like encoding/binary, they panic if the slice
+// is too short. High-low swap can be applied with SwapHL32 and SwapHL64.
+
+// PutU8Into writes v at dst[off] and returns off+1.
+func PutU8Into(dst []byte, off int, v uint8) int {
+	dst[off] = v
+	return off + 1
+}
+
+// PutU16Into writes v in byte order e at dst[off:] and returns off+2.
+func PutU16Into(dst []byte, off int, v uint16, e Endian) int {
+	if e == LittleEndian {
+		binary.LittleEndian.PutUint16(dst[off:], v)
+	} else {
+		binary.BigEndian.PutUint16(dst[off:], v)
+	}
+	return off + 2
+}
+
+// PutU32Into writes v in byte order e at dst[off:] and returns off+4.
+func PutU32Into(dst []byte, off int, v uint32, e Endian) int {
+	if e == LittleEndian {
+		binary.LittleEndian.PutUint32(dst[off:], v)
+	} else {
+		binary.BigEndian.PutUint32(dst[off:], v)
+	}
+	return off + 4
+}
+
+// PutU64Into writes v in byte order e at dst[off:] and returns off+8.
+func PutU64Into(dst []byte, off int, v uint64, e Endian) int {
+	if e == LittleEndian {
+		binary.LittleEndian.PutUint64(dst[off:], v)
+	} else {
+		binary.BigEndian.PutUint64(dst[off:], v)
+	}
+	return off + 8
+}
+
+// TakeU8From reads a uint8 at src[off] and returns it with off+1.
+func TakeU8From(src []byte, off int) (uint8, int) {
+	return src[off], off + 1
+}
+
+// TakeU16From reads a uint16 in byte order e at src[off:] and returns it with off+2.
+func TakeU16From(src []byte, off int, e Endian) (uint16, int) {
+	if e == LittleEndian {
+		return binary.LittleEndian.Uint16(src[off:]), off + 2
+	}
+	return binary.BigEndian.Uint16(src[off:]), off + 2
+}
+
+// TakeU32From reads a uint32 in byte order e at src[off:] and returns it with off+4.
+func TakeU32From(src []byte, off int, e Endian) (uint32, int) {
+	if e == LittleEndian {
+		return binary.LittleEndian.Uint32(src[off:]), off + 4
+	}
+	return binary.BigEndian.Uint32(src[off:]), off + 4
+}
+
+// TakeU64From reads a uint64 in byte order e at src[off:] and returns it with off+8.
+func TakeU64From(src []byte, off int, e Endian) (uint64, int) {
+	if e == LittleEndian {
+		return binary.LittleEndian.Uint64(src[off:]), off + 8
+	}
+	return binary.BigEndian.Uint64(src[off:]), off + 8
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestInto tests that the package-level functions match the Buffer methods.
+func TestInto(t *testing.T) {
+	for _, e := range []Endian{BigEndian, LittleEndian} {
+		for _, swap := range []bool{false, true} {
+			b := NewBuffer(15)
+			b.SetEndian(e)
+			b.SetHLSwap(swap)
+			b.PutU8(0x01)
+			b.PutU16(0x0203)
+			b.PutU32(0x04050607)
+			b.PutU64(0x08090A0B0C0D0E0F)
+
+			v32, v64 := uint32(0x04050607), uint64(0x08090A0B0C0D0E0F)
+			if swap {
+				v32, v64 = SwapHL32(v32), SwapHL64(v64)
+			}
+			dst := make([]byte, 15)
+			off := PutU8Into(dst, 0, 0x01)
+			off = PutU16Into(dst, off, 0x0203, e)
+			off = PutU32Into(dst, off, v32, e)
+			off = PutU64Into(dst, off, v64, e)
+			assert.Equal(t, 15, off)
+			assert.Equal(t, b.Bytes(), dst)
+
+			u8, off := TakeU8From(dst, 0)
+			u16, off := TakeU16From(dst, off, e)
+			u32, off := TakeU32From(dst, off, e)
+			u64, off := TakeU64From(dst, off, e)
+			assert.Equal(t, uint8(0x01), u8)
+			assert.Equal(t, uint16(0x0203), u16)
+			assert.Equal(t, v32, u32)
+			assert.Equal(t, v64, u64)
+			assert.Equal(t, 15, off)
+		}
+	}
+
+	assert.Equal(t, uint32(0x22114433), SwapHL32(0x11223344))
+	assert.Equal(t, uint64(0x2211443366558877), SwapHL64(0x1122334455667788))
+	assert.Panics(t, func() { PutU32Into(make([]byte, 3), 0, 1, BigEndian) })
+}
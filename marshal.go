@@ -0,0 +1,317 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// fieldCodec describes how a scalar field is encoded.
+type fieldCodec struct {
+	width int              // encoded width in bytes
+	order binary.ByteOrder // byte order, or nil for the buffer's
+}
+
+// parseFieldTag parses an `mbuff:"..."` tag of comma-separated options:
+// a width of u8/u16/u32/u64 (or i8..i64), and an order of le/be.
+// It reports skip for "-".
+func parseFieldTag(tag string) (c fieldCodec, skip bool, err error) {
+	if tag == "-" {
+		return c, true, nil
+	}
+	if tag == "" {
+		return c, false, nil
+	}
+	for _, opt := range strings.Split(tag, ",") {
+		switch opt {
+		case "u8", "i8":
+			c.width = 1
+		case "u16", "i16":
+			c.width = 2
+		case "u32", "i32":
+			c.width = 4
+		case "u64", "i64":
+			c.width = 8
+		case "le":
+			c.order = binary.LittleEndian
+		case "be":
+			c.order = binary.BigEndian
+		default:
+			return c, false, fmt.Errorf("unknown tag option %q", opt)
+		}
+	}
+	return c, false, nil
+}
+
+// scalarWidth returns the natural encoded width of a scalar kind, or 0 if
+// the kind is not a supported scalar.
+func scalarWidth(k reflect.Kind) int {
+	switch k {
+	case reflect.Bool, reflect.Int8, reflect.Uint8:
+		return 1
+	case reflect.Int16, reflect.Uint16:
+		return 2
+	case reflect.Int32, reflect.Uint32, reflect.Float32:
+		return 4
+	case reflect.Int64, reflect.Uint64, reflect.Float64:
+		return 8
+	}
+	return 0
+}
+
+// marshaler walks a value and emits its encoding through put.
+type marshaler struct {
+	b   *Buffer
+	put func(p []byte)
+}
+
+func (m *marshaler) value(v reflect.Value, c fieldCodec, path string) error {
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			fc, skip, err := parseFieldTag(f.Tag.Get("mbuff"))
+			if err != nil {
+				return fmt.Errorf("%s.%s: %w", path, f.Name, err)
+			}
+			if skip {
+				continue
+			}
+			if err := m.value(v.Field(i), fc, path+"."+f.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := m.value(v.Index(i), c, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	width, raw, err := scalarBits(v, c, path)
+	if err != nil {
+		return err
+	}
+	if !fitsWidth(v.Kind(), raw, width) {
+		return fmt.Errorf("%s: value %v does not fit in %d bytes", path, v, width)
+	}
+	var tmp [8]byte
+	m.b.encodeScalar(tmp[:width], raw, c.order)
+	m.put(tmp[:width])
+	return nil
+}
+
+// codecWidth returns the encoded width of a scalar of kind k with codec c.
+func codecWidth(k reflect.Kind, c fieldCodec, path string) (int, error) {
+	width := scalarWidth(k)
+	if width == 0 {
+		return 0, fmt.Errorf("%s: unsupported kind %s", path, k)
+	}
+	if c.width != 0 {
+		if isFloatKind(k) && c.width != width {
+			return 0, fmt.Errorf("%s: width tag not allowed on %s", path, k)
+		}
+		width = c.width
+	}
+	return width, nil
+}
+
+// scalarBits returns the encoded width and raw bits of a scalar value.
+func scalarBits(v reflect.Value, c fieldCodec, path string) (int, uint64, error) {
+	width, err := codecWidth(v.Kind(), c, path)
+	if err != nil {
+		return 0, 0, err
+	}
+	var raw uint64
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			raw = 1
+		}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		raw = uint64(v.Int())
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		raw = v.Uint()
+	case reflect.Float32:
+		raw = uint64(math.Float32bits(float32(v.Float())))
+	case reflect.Float64:
+		raw = math.Float64bits(v.Float())
+	}
+	return width, raw, nil
+}
+
+// fitsWidth reports whether raw bits of kind k survive truncation to width bytes.
+func fitsWidth(k reflect.Kind, raw uint64, width int) bool {
+	if width == 8 {
+		return true
+	}
+	shift := 64 - 8*width
+	switch k {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int64(raw<<shift)>>shift == int64(raw)
+	}
+	return raw>>(8*width) == 0
+}
+
+func isFloatKind(k reflect.Kind) bool { return k == reflect.Float32 || k == reflect.Float64 }
+
+// encodeScalar writes the low len(p) bytes of raw into p.
+func (b *Buffer) encodeScalar(p []byte, raw uint64, order binary.ByteOrder) {
+	if order == nil {
+		order = b.order
+	}
+	switch len(p) {
+	case 1:
+		p[0] = uint8(raw)
+	case 2:
+		order.PutUint16(p, uint16(raw))
+	case 4:
+		order.PutUint32(p, b.HLSwap32(uint32(raw)))
+	default:
+		order.PutUint64(p, b.HLSwap64(raw))
+	}
+}
+
+// decodeScalar reads raw bits from p.
+func (b *Buffer) decodeScalar(p []byte, order binary.ByteOrder) uint64 {
+	if order == nil {
+		order = b.order
+	}
+	switch len(p) {
+	case 1:
+		return uint64(p[0])
+	case 2:
+		return uint64(order.Uint16(p))
+	case 4:
+		return uint64(b.HLSwap32(order.Uint32(p)))
+	default:
+		return b.HLSwap64(order.Uint64(p))
+	}
+}
+
+// marshal encodes the struct v (or pointer to struct) through put.
+func (b *Buffer) marshal(method string, v any, put func(p []byte)) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("mbuff.%s: expected struct, got %T", method, v)
+	}
+	m := &marshaler{b: b, put: put}
+	if err := m.value(rv, fieldCodec{}, rv.Type().Name()); err != nil {
+		return fmt.Errorf("mbuff.%s: %w", method, err)
+	}
+	return b.err
+}
+
+// Marshal writes the exported fields of the struct v (or pointer to struct)
+// in declaration order, recursing into nested structs and arrays, then
+// advances the position. Scalars use their natural width and the buffer's
+// byte order unless overridden by an `mbuff:"..."` tag holding a width
+// (u8, u16, u32, u64 or i8..i64) and/or an order (le, be); "-" skips the
+// field. Bools are one byte. Unsupported kinds return an error, possibly
+// after earlier fields have been written.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) Marshal(v any) error {
+	return b.marshal("Buffer.Marshal", v, b.PutArr8)
+}
+
+// Unmarshal reads into the struct pointed to by v, mirroring Marshal, then
+// advances the position.
+func (b *Buffer) Unmarshal(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("mbuff.Buffer.Unmarshal: expected non-nil pointer to struct, got %T", v)
+	}
+	if err := b.unmarshalValue(rv.Elem(), fieldCodec{}, rv.Elem().Type().Name()); err != nil {
+		return fmt.Errorf("mbuff.Buffer.Unmarshal: %w", err)
+	}
+	return b.err
+}
+
+func (b *Buffer) unmarshalValue(v reflect.Value, c fieldCodec, path string) error {
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			fc, skip, err := parseFieldTag(f.Tag.Get("mbuff"))
+			if err != nil {
+				return fmt.Errorf("%s.%s: %w", path, f.Name, err)
+			}
+			if skip {
+				continue
+			}
+			if err := b.unmarshalValue(v.Field(i), fc, path+"."+f.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := b.unmarshalValue(v.Index(i), c, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	width, _, err := scalarBits(v, c, path)
+	if err != nil {
+		return err
+	}
+	if !b.checkReadable(width) {
+		return nil
+	}
+	raw := b.decodeScalar(b.data[b.pos:b.pos+width], c.order)
+	if err := setScalar(v, raw, width, path); err != nil {
+		return err
+	}
+	b.pos += width
+	return nil
+}
+
+// setScalar stores raw bits decoded from width bytes into the scalar v,
+// sign-extending signed integers. Returns an error, leaving v unchanged, if
+// the decoded value does not fit in v's kind.
+func setScalar(v reflect.Value, raw uint64, width int, path string) error {
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(raw != 0)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		shift := 64 - 8*width
+		n := int64(raw<<shift) >> shift
+		if v.OverflowInt(n) {
+			return fmt.Errorf("%s: decoded value %d overflows %s", path, n, v.Kind())
+		}
+		v.SetInt(n)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.OverflowUint(raw) {
+			return fmt.Errorf("%s: decoded value %d overflows %s", path, raw, v.Kind())
+		}
+		v.SetUint(raw)
+	case reflect.Float32:
+		v.SetFloat(float64(math.Float32frombits(uint32(raw))))
+	case reflect.Float64:
+		v.SetFloat(math.Float64frombits(raw))
+	}
+	return nil
+}
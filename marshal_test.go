@@ -0,0 +1,116 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testHeader struct {
+	Version uint8
+	Flags   uint16 `mbuff:"le"`
+	Length  uint64 `mbuff:"u32"`
+}
+
+type testMessage struct {
+	Header  testHeader
+	Offset  int32 `mbuff:"i16"`
+	Valid   bool
+	Scale   float32
+	Samples [3]uint16
+	Ignored string `mbuff:"-"`
+	private int
+}
+
+// TestMarshal tests reflection-based struct encoding.
+func TestMarshal(t *testing.T) {
+	in := testMessage{
+		Header:  testHeader{Version: 1, Flags: 0x0203, Length: 0x04050607},
+		Offset:  -2,
+		Valid:   true,
+		Scale:   1.5,
+		Samples: [3]uint16{0x0A0B, 0x0C0D, 0x0E0F},
+		Ignored: "skipped",
+		private: 7,
+	}
+
+	b := NewBuilder(0)
+	assert.NoError(t, b.Marshal(&in))
+	expected := []byte{
+		0x01,       // Version
+		0x03, 0x02, // Flags, little endian
+		0x04, 0x05, 0x06, 0x07, // Length as u32
+		0xFF, 0xFE, // Offset as i16
+		0x01,                   // Valid
+		0x3F, 0xC0, 0x00, 0x00, // Scale
+		0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F, // Samples
+	}
+	assert.Equal(t, expected, b.Bytes())
+
+	var out testMessage
+	b.Rewind()
+	assert.NoError(t, b.Unmarshal(&out))
+	in.Ignored, in.private = "", 0
+	assert.Equal(t, in, out)
+	assert.Equal(t, 0, b.Readable())
+
+	// Values encoded by value and into a fixed buffer match
+	fixed := NewBuffer(len(expected))
+	assert.NoError(t, fixed.Marshal(in))
+	assert.Equal(t, expected, fixed.Bytes())
+}
+
+// TestMarshal_Errors tests rejecting unsupported input.
+func TestMarshal_Errors(t *testing.T) {
+	b := NewBuilder(0)
+	assert.Error(t, b.Marshal(42))
+	assert.Error(t, b.Marshal(struct{ S string }{}))
+	assert.Error(t, b.Marshal(struct {
+		V uint8 `mbuff:"x8"`
+	}{}))
+	assert.Error(t, b.Marshal(struct {
+		F float64 `mbuff:"u32"`
+	}{}))
+	assert.Error(t, b.Marshal(struct {
+		V uint32 `mbuff:"u8"`
+	}{V: 256}))
+	assert.Error(t, b.Marshal(struct {
+		V int32 `mbuff:"i8"`
+	}{V: -129}))
+
+	var h testHeader
+	assert.Error(t, b.Unmarshal(h))
+	assert.Error(t, b.Unmarshal((*testHeader)(nil)))
+
+	// Truncated input
+	r := NewBufferFrom([]byte{0x01, 0x02})
+	assert.Panics(t, func() { _ = r.Unmarshal(&h) })
+	r = NewBufferFrom([]byte{0x01, 0x02})
+	r.SetStrictMode(true)
+	assert.Error(t, r.Unmarshal(&h))
+}
+
+// TestUnmarshal_Overflow tests rejecting decoded values wider than the field.
+func TestUnmarshal_Overflow(t *testing.T) {
+	type narrow struct {
+		U uint8 `mbuff:"u32"`
+		I int8  `mbuff:"i16"`
+	}
+	var v narrow
+	r := NewBufferFrom([]byte{0, 0, 0, 0xFF, 0xFF, 0x80})
+	assert.NoError(t, r.Unmarshal(&v))
+	assert.Equal(t, narrow{U: 255, I: -128}, v)
+
+	r = NewBufferFrom([]byte{0, 0, 1, 0, 0, 0})
+	assert.ErrorContains(t, r.Unmarshal(&v), "narrow.U: decoded value 256 overflows uint8")
+	assert.Equal(t, 0, r.Pos())
+
+	r = NewBufferFrom([]byte{0, 0, 0, 1, 0xFF, 0x7F})
+	assert.ErrorContains(t, r.Unmarshal(&v), "narrow.I: decoded value -129 overflows int8")
+	assert.Equal(t, 4, r.Pos())
+}
@@ -6,8 +6,10 @@
 package mbuff
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
+	"hash"
 	"io"
 )
 
@@ -43,11 +45,35 @@ import (
 //	Byte order and high-low swap:
 //	  - order:  Byte order for handling different endianness.
 //	  - hlswap: Flag to enable/disable high-low byte swap for 32-bit and 64-bit types.
+//
+//	Failure mode:
+//	  - errMode: Flag to record bounds violations as a sticky error instead of panicking.
+//	  - err:     First recorded bounds violation in error mode.
+//
+//	Tracing:
+//	  - tracing: Flag to record operations for diagnostics.
+//	  - trace:   Operations recorded while tracing is enabled.
+//
+//	Running checksum:
+//	  - csHash: Hash updated with the consumed bytes, or nil when disabled.
+//	  - csPos:  Position up to which csHash has been updated.
+//
+//	Markers:
+//	  - marks:  Positions recorded by MarkNamed.
+//	  - pushed: Positions pushed by Mark, most recent last.
 type Buffer struct {
-	data   []byte           // underlying byte array
-	pos    int              // current position
-	order  binary.ByteOrder // byte order
-	hlswap bool             // whether high-low swap is enabled
+	data    []byte           // underlying byte array
+	pos     int              // current position
+	order   binary.ByteOrder // byte order
+	hlswap  bool             // whether high-low swap is enabled
+	errMode bool             // whether bounds violations are recorded instead of panicking
+	err     error            // sticky error recorded in error mode
+	tracing bool             // whether operations are recorded
+	trace   []TraceOp        // recorded operations
+	csHash  hash.Hash32      // running checksum of consumed bytes
+	csPos   int              // position up to which csHash is updated
+	marks   map[string]int   // named positions
+	pushed  []int            // positions pushed by Mark
 }
 
 // New creates a new Buffer with the specified initial capacity.
@@ -79,24 +105,68 @@ func NewBufferFrom(buffer []byte) *Buffer {
 	}
 }
 
+// NewBufferFromAt creates a new Buffer over the given buffer where only the
+// first count bytes are valid and the position is pos. The buffer's capacity
+// is preserved. This is useful for reconstructing a partially-filled buffer.
+// Returns an error unless 0 <= pos <= count <= len(buffer).
+func NewBufferFromAt(buffer []byte, count, pos int) (*Buffer, error) {
+	if count < 0 || count > len(buffer) {
+		return nil, fmt.Errorf("mbuff.NewBufferFromAt: count %d out of bounds [0, %d]", count, len(buffer))
+	}
+	if pos < 0 || pos > count {
+		return nil, fmt.Errorf("mbuff.NewBufferFromAt: pos %d out of bounds [0, %d]", pos, count)
+	}
+	return &Buffer{
+		data:   buffer[:count],
+		pos:    pos,
+		order:  binary.BigEndian,
+		hlswap: false,
+	}, nil
+}
+
 // Capacity returns the total capacity of the buffer.
 func (b *Buffer) Capacity() int { return cap(b.data) }
 
 // Count returns the length of valid data in the buffer.
 func (b *Buffer) Count() int { return len(b.data) }
 
+// Len is an alias of Count, for code migrating from bytes.Buffer. Unlike
+// bytes.Buffer.Len, bytes already read are included, since reads and writes
+// share one position; use Readable for the unread length.
+func (b *Buffer) Len() int { return len(b.data) }
+
+// Cap is an alias of Capacity, for code migrating from bytes.Buffer.
+func (b *Buffer) Cap() int { return cap(b.data) }
+
 // Pos returns the current position.
 func (b *Buffer) Pos() int { return b.pos }
 
 // Readable returns the length of readable data (count - pos).
 func (b *Buffer) Readable() int { return len(b.data) - b.pos }
 
+// AtEnd checks if all data has been read (pos == count).
+func (b *Buffer) AtEnd() bool { return b.pos >= len(b.data) }
+
 // Writable returns the length of writable space from current position (capacity - pos).
 func (b *Buffer) Writable() int { return cap(b.data) - b.pos }
 
 // Appendable returns the length of appendable space (capacity - len).
 func (b *Buffer) Appendable() int { return cap(b.data) - len(b.data) }
 
+// IsAligned checks if the current position is a multiple of n.
+// Panics if n is not positive.
+func (b *Buffer) IsAligned(n int) bool { return b.Misalignment(n) == 0 }
+
+// Misalignment returns the number of bytes from the current position to the
+// next position that is a multiple of n, or 0 if it is already aligned.
+// Panics if n is not positive.
+func (b *Buffer) Misalignment(n int) int {
+	if n <= 0 {
+		panic("mbuff.Buffer.Misalignment: non-positive alignment")
+	}
+	return (n - b.pos%n) % n
+}
+
 // IsEmpty checks if the buffer is empty (len == 0).
 func (b *Buffer) IsEmpty() bool { return len(b.data) == 0 }
 
@@ -109,21 +179,64 @@ func (b *Buffer) Data() []byte { return b.data[:cap(b.data)] }
 // Bytes returns the slice of valid data (from 0 to len).
 func (b *Buffer) Bytes() []byte { return b.data }
 
+// String returns the valid data (from 0 to len) as a string. Unlike
+// bytes.Buffer.String, bytes already read are included, since reads and
+// writes share one position; use ReadableBytes for the unread part.
+func (b *Buffer) String() string { return string(b.data) }
+
+// ProcessedBytes returns the slice of processed data (from 0 to pos).
+// The slice aliases the buffer; copy it to keep it past Compact or writes.
+func (b *Buffer) ProcessedBytes() []byte { return b.data[:b.pos] }
+
 // ReadableBytes returns the slice of readable data (from pos to len).
 func (b *Buffer) ReadableBytes() []byte { return b.data[b.pos:] }
 
+// WindowBytes returns up to the last k processed bytes followed by the
+// readable data (from max(0, pos-k) to len), so scanners can match patterns
+// straddling pos. Compact discards processed bytes, so after Compact the
+// window holds only readable data; to keep a look-behind across refills,
+// Compact only once more than k bytes have been processed and Skip back
+// accordingly. Panics if k is negative.
+func (b *Buffer) WindowBytes(k int) []byte {
+	if k < 0 {
+		panic("mbuff.Buffer.WindowBytes: negative window")
+	}
+	start := b.pos - k
+	if start < 0 {
+		start = 0
+	}
+	return b.data[start:]
+}
+
 // WritableBytes returns the slice of writable data (from pos to capacity).
 func (b *Buffer) WritableBytes() []byte { return b.data[b.pos:] }
 
 // Rewind resets the position to 0.
-func (b *Buffer) Rewind() { b.pos = 0 }
+func (b *Buffer) Rewind() { b.setPos(0) }
 
 // Clear clears the buffer by resetting both position and length to 0.
 func (b *Buffer) Clear() {
-	b.pos = 0
+	b.setPos(0)
 	b.data = b.data[:0]
 }
 
+// Reset is an alias of Clear, for code migrating from bytes.Buffer.
+func (b *Buffer) Reset() { b.Clear() }
+
+// Truncate discards all but the first n bytes of valid data, moving the
+// position back to n if it was beyond. The capacity is unchanged, so the
+// backing array can be reused by later writes. Panics if n is negative or
+// greater than the count.
+func (b *Buffer) Truncate(n int) {
+	if n < 0 || n > len(b.data) {
+		panic(fmt.Errorf("mbuff.Buffer.Truncate: truncation to %d out of bounds [0, %d]", n, len(b.data)))
+	}
+	b.data = b.data[:n]
+	if b.pos > n {
+		b.setPos(n)
+	}
+}
+
 // SetEndian sets the byte order for reading/writing multi-byte values.
 func (b *Buffer) SetEndian(e Endian) {
 	if e == LittleEndian {
@@ -144,13 +257,41 @@ func (b *Buffer) GetEndian() Endian {
 // SetHLSwap enables or disables high-low byte swap for 32/64-bit types.
 func (b *Buffer) SetHLSwap(enable bool) { b.hlswap = enable }
 
+// SetStrictMode selects how bounds-checked operations (the Put, Take, Peek,
+// Overwrite and Patch families) fail. By default they panic with an error
+// describing the violation, which wraps ErrOutOfRange for bounds failures
+// and can be inspected after recover with errors.Is. With
+// errorsNotPanics set, the first violation is recorded and returned by Err,
+// and every bounds-checked operation becomes a no-op (returning zero values)
+// until ClearErr is called, in the style of bufio.Scanner.
+func (b *Buffer) SetStrictMode(errorsNotPanics bool) { b.errMode = errorsNotPanics }
+
+// Err returns the first bounds violation recorded in error mode, or nil.
+func (b *Buffer) Err() error { return b.err }
+
+// ClearErr clears the recorded error so that operations resume.
+func (b *Buffer) ClearErr() { b.err = nil }
+
+// fail reports a bounds violation. It panics with err by default; in error
+// mode it records err as the sticky error instead. It always returns false so
+// callers can bail out with "return b.fail(...)".
+func (b *Buffer) fail(err error) bool {
+	if !b.errMode {
+		panic(err)
+	}
+	if b.err == nil {
+		b.err = err
+	}
+	return false
+}
+
 // Seek moves the position to the specified offset from the start.
 // The offset must be within [0, len].
 func (b *Buffer) Seek(offset int) error {
 	if offset < 0 || offset > len(b.data) {
 		return fmt.Errorf("mbuff.Buffer.Seek: seek offset %d out of bounds [0, %d]", offset, len(b.data))
 	}
-	b.pos = offset
+	b.setPos(offset)
 	return nil
 }
 
@@ -160,7 +301,7 @@ func (b *Buffer) Reseek(offset int) error {
 	if offset < 0 || offset > len(b.data) {
 		return fmt.Errorf("mbuff.Buffer.Reseek: reseek offset %d out of bounds [0, %d]", offset, len(b.data))
 	}
-	b.pos = len(b.data) - offset
+	b.setPos(len(b.data) - offset)
 	return nil
 }
 
@@ -175,10 +316,22 @@ func (b *Buffer) Skip(length int) int {
 	if length > readable {
 		length = readable
 	}
-	b.pos += length
+	b.setPos(b.pos + length)
 	return length
 }
 
+// SkipOrErr is the strict counterpart of Skip for parsers that must notice
+// nonsensical computed sizes: instead of clamping, it returns an error
+// wrapping ErrOutOfRange, without advancing, if length is negative or
+// exceeds Readable(). Returns the amount advanced.
+func (b *Buffer) SkipOrErr(length int) (int, error) {
+	if length < 0 || length > b.Readable() {
+		return 0, fmt.Errorf("mbuff.Buffer.SkipOrErr: skip of %d bytes at pos %d exceeds count %d: %w", length, b.pos, len(b.data), ErrOutOfRange)
+	}
+	b.setPos(b.pos + length)
+	return length, nil
+}
+
 // Commit clamps advancement to Writable() to prevent stepping beyond capacity.
 // Unlike Skip, it extends len(data) when pos crosses current length so newly
 // produced bytes become visible to readers. This separates capacity reservation
@@ -202,7 +355,8 @@ func (b *Buffer) Commit(length int) int {
 // avoid allocation and copying. s < 0 means "from current pos", e < 0 means
 // "to len(data)". It preserves endianness and swap settings. Because the view
 // shares storage, mutations via either buffer are reflected in both; invalid
-// ranges fail fast with a panic.
+// ranges fail fast with a panic. The view inherits the failure mode but not
+// any recorded error.
 func (b *Buffer) Since(s, e int) *Buffer {
 	if s < 0 {
 		s = b.pos
@@ -214,13 +368,76 @@ func (b *Buffer) Since(s, e int) *Buffer {
 		panic("invalid range")
 	}
 	return &Buffer{
-		data:   b.data[s:e:cap(b.data)],
-		pos:    0,
-		order:  b.order,
-		hlswap: b.hlswap,
+		data:    b.data[s:e:cap(b.data)],
+		pos:     0,
+		order:   b.order,
+		hlswap:  b.hlswap,
+		errMode: b.errMode,
+	}
+}
+
+// Clone returns an independent deep copy of b: a fresh backing array of the
+// same capacity holding a copy of the valid data [0:len], with b's position,
+// byte order, swap settings and failure mode. Unlike Since, which is a view
+// sharing b's storage, writes to either buffer are never seen by the other.
+// Recorded errors, marks, tracing and running checksums are not copied.
+func (b *Buffer) Clone() *Buffer {
+	data := make([]byte, len(b.data), cap(b.data))
+	copy(data, b.data)
+	return &Buffer{
+		data:    data,
+		pos:     b.pos,
+		order:   b.order,
+		hlswap:  b.hlswap,
+		errMode: b.errMode,
 	}
 }
 
+// Chunks splits the valid data [0:len] into zero-copy views of size bytes
+// each; the final view may be shorter. The views share storage with b, so
+// mutations through either are reflected in both, but each view's capacity
+// ends where it does, so writes to one view cannot spill into the next. Like
+// Since, the views preserve endianness, swap settings and the failure mode.
+// Panics if size is not positive.
+func (b *Buffer) Chunks(size int) []*Buffer {
+	return b.chunks("Chunks", size, false)
+}
+
+// CopyChunks is like Chunks but each chunk owns a copy of its bytes, so the
+// chunks can be used concurrently and independently of b.
+func (b *Buffer) CopyChunks(size int) []*Buffer {
+	return b.chunks("CopyChunks", size, true)
+}
+
+// chunks implements Chunks and CopyChunks.
+func (b *Buffer) chunks(method string, size int, clone bool) []*Buffer {
+	if size <= 0 {
+		panic("mbuff.Buffer." + method + ": non-positive size")
+	}
+	if len(b.data) == 0 {
+		return nil
+	}
+	out := make([]*Buffer, 0, (len(b.data)+size-1)/size)
+	for s := 0; s < len(b.data); s += size {
+		e := min(s+size, len(b.data))
+		data := b.data[s:e:e]
+		if clone {
+			data = bytes.Clone(data)
+		}
+		out = append(out, &Buffer{
+			data:    data,
+			order:   b.order,
+			hlswap:  b.hlswap,
+			errMode: b.errMode,
+		})
+	}
+	return out
+}
+
+// ProcessedSince returns a zero-copy view over the processed region [0:pos],
+// e.g. to log or audit a message after decoding it.
+func (b *Buffer) ProcessedSince() *Buffer { return b.Since(0, b.pos) }
+
 // ReadableSince returns a zero-copy view over the current readable region to
 // let downstream parsers operate on valid bytes without copying.
 func (b *Buffer) ReadableSince() *Buffer { return b.Since(b.pos, len(b.data)) }
@@ -231,24 +448,45 @@ func (b *Buffer) ReadableSince() *Buffer { return b.Since(b.pos, len(b.data)) }
 func (b *Buffer) WritableSince() *Buffer { return b.Since(b.pos, len(b.data)) }
 
 // Compact compacts the buffer by moving readable data [pos:len] to [0:len-pos],
-// then resets pos to 0 and adjusts data length.
+// then resets pos to 0 and adjusts data length. Named and pushed marks move
+// with the data; marks in the discarded region move to 0.
 func (b *Buffer) Compact() {
-	if b.pos == 0 {
-		return
-	}
-	if b.pos == len(b.data) {
-		b.Clear()
+	shift := b.pos
+	if shift == 0 {
 		return
 	}
 
-	readableLen := b.Readable()
-	if readableLen > 0 {
-		copy(b.data[0:readableLen], b.data[b.pos:])
+	b.syncChecksum()
+	copy(b.data, b.data[shift:])
+	b.data = b.data[:len(b.data)-shift]
+	b.movePositions(deleteMove(0, shift))
+}
+
+// Diff compares the valid data [0:len] of both buffers, ignoring position and
+// capacity. It returns the index of the first differing byte, or the shorter
+// length if one is a prefix of the other; offset is -1 when they are equal.
+func (b *Buffer) Diff(other *Buffer) (offset int, equal bool) {
+	n := len(b.data)
+	if len(other.data) < n {
+		n = len(other.data)
 	}
-	b.data = b.data[:readableLen]
-	b.pos = 0
+	for i := 0; i < n; i++ {
+		if b.data[i] != other.data[i] {
+			return i, false
+		}
+	}
+	if len(b.data) != len(other.data) {
+		return n, false
+	}
+	return -1, true
 }
 
+// ContentEqual reports whether a and b hold the same valid data [0:len],
+// ignoring position, capacity and settings. It is meant for test assertions
+// such as assert.True(t, mbuff.ContentEqual(a, b)); use Diff to locate the
+// first difference.
+func ContentEqual(a, b *Buffer) bool { return bytes.Equal(a.data, b.data) }
+
 // Peek reads data from the current position into p without advancing the position.
 // Returns the actual number of bytes read.
 func (b *Buffer) Peek(p []byte) (n int) {
@@ -294,6 +532,23 @@ func (b *Buffer) Fill(bt byte, length int) int {
 	return length
 }
 
+// ZeroFill appends length zero bytes after the current count without moving
+// the position, and returns the number of bytes appended.
+// It fills up to the available appendable space.
+func (b *Buffer) ZeroFill(length int) int {
+	if length <= 0 {
+		return 0
+	}
+	if appendable := b.Appendable(); length > appendable {
+		length = appendable
+	}
+
+	start := len(b.data)
+	b.data = b.data[:start+length]
+	clear(b.data[start:])
+	return length
+}
+
 // Read reads data from the buffer into p.
 // It implements the io.Reader interface.
 // Reads at most len(p) or b.Readable() bytes.
@@ -316,9 +571,83 @@ func (b *Buffer) Read(p []byte) (n int, err error) {
 	return
 }
 
+// ReadEOF is like Read but returns io.EOF together with the final bytes when
+// the read exhausts the buffer, instead of on the following call. This suits
+// callers that stop on the first io.EOF; plain Read follows the io.Reader
+// convention of bytes.Reader, where the last bytes come with a nil error.
+func (b *Buffer) ReadEOF(p []byte) (n int, err error) {
+	n, err = b.Read(p)
+	if err == nil && b.pos == len(b.data) {
+		err = io.EOF
+	}
+	return
+}
+
+// ReadByte reads and returns the byte at the current position, then advances the position.
+// It implements the io.ByteReader interface.
+// If no byte is readable, it returns io.EOF.
+func (b *Buffer) ReadByte() (byte, error) {
+	if b.pos >= len(b.data) {
+		return 0, io.EOF
+	}
+	c := b.data[b.pos]
+	b.pos++
+	return c, nil
+}
+
+// WriteTo writes the readable data to w and advances the position by the
+// number of bytes written. It implements the io.WriterTo interface, so
+// io.Copy from a Buffer hands the readable region to w in a single Write
+// without an intermediate scratch buffer; a Builder destination grows once
+// and copies it directly.
+func (b *Buffer) WriteTo(w io.Writer) (n int64, err error) {
+	readable := b.data[b.pos:]
+	if len(readable) == 0 {
+		return 0, nil
+	}
+	m, err := w.Write(readable)
+	if m < 0 || m > len(readable) {
+		panic("mbuff.Buffer.WriteTo: invalid Write count")
+	}
+	b.pos += m
+	if err == nil && m != len(readable) {
+		err = io.ErrShortWrite
+	}
+	return int64(m), err
+}
+
+// limitReader reads from a Buffer and stops with io.EOF after n bytes.
+type limitReader struct {
+	b *Buffer
+	n int
+}
+
+// Read implements io.Reader.
+func (r *limitReader) Read(p []byte) (n int, err error) {
+	if r.n <= 0 {
+		return 0, io.EOF
+	}
+	if len(p) > r.n {
+		p = p[:r.n]
+	}
+	n, err = r.b.Read(p)
+	r.n -= n
+	return
+}
+
+// LimitReader returns an io.Reader over at most the next n readable bytes.
+// Unlike Since, the reader is coupled to b: every Read advances b's position,
+// so after a decoder consumes the reader, b is positioned just past the bytes
+// it read. The reader reports io.EOF after n bytes or when b has no more
+// readable data, whichever comes first.
+func (b *Buffer) LimitReader(n int) io.Reader {
+	return &limitReader{b: b, n: n}
+}
+
 // Write writes data from p into the buffer.
 // It implements the io.Writer interface.
-// It writes up to the available writable space.
+// It writes up to the available writable space. A nil or empty p is a no-op
+// returning (0, nil).
 func (b *Buffer) Write(p []byte) (n int, err error) {
 	if len(p) == 0 {
 		return
@@ -337,3 +666,29 @@ func (b *Buffer) Write(p []byte) (n int, err error) {
 	b.pos += n
 	return
 }
+
+// WriteAt writes p at offset off without moving the position, extending the
+// count if needed and zero-filling any gap after it, as the Patch methods do.
+// It implements the io.WriterAt interface and writes up to the capacity,
+// returning io.ErrShortWrite if p does not fit. A nil or empty p is a no-op
+// returning (0, nil). Returns an error if off is negative or beyond the
+// capacity.
+func (b *Buffer) WriteAt(p []byte, off int64) (n int, err error) {
+	if off < 0 || off > int64(cap(b.data)) {
+		return 0, fmt.Errorf("mbuff.Buffer.WriteAt: offset %d out of bounds [0, %d]: %w", off, cap(b.data), ErrOutOfRange)
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	offset := int(off)
+	n = min(len(p), cap(b.data)-offset)
+	if n < len(p) {
+		err = io.ErrShortWrite
+	}
+	if !b.checkPatchable(offset, n) {
+		return 0, b.err
+	}
+	copy(b.data[offset:offset+n], p)
+	return
+}
@@ -6,7 +6,9 @@
 package mbuff
 
 import (
+	"bytes"
 	"encoding/binary"
+	"fmt"
 	"io"
 	"testing"
 
@@ -101,6 +103,33 @@ func TestNewBufferFrom(t *testing.T) {
 	assert.Equal(t, byte(0xFF), original[0]) // original should be modified
 }
 
+// TestNewBufferFromAt tests wrapping a partially-filled buffer.
+func TestNewBufferFromAt(t *testing.T) {
+	data := make([]byte, 8, 12)
+	copy(data, []byte{0x12, 0x34, 0x56, 0x78, 0xAB, 0xCD, 0xEF, 0x01})
+
+	b, err := NewBufferFromAt(data, 6, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 12, b.Capacity())
+	assert.Equal(t, 6, b.Count())
+	assert.Equal(t, 2, b.Pos())
+	assert.Equal(t, uint32(0x5678ABCD), b.TakeU32())
+	assert.Equal(t, 0, b.Readable())
+
+	b, err = NewBufferFromAt(data, 8, 8)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, b.Readable())
+
+	_, err = NewBufferFromAt(data, 9, 0)
+	assert.Error(t, err)
+	_, err = NewBufferFromAt(data, -1, 0)
+	assert.Error(t, err)
+	_, err = NewBufferFromAt(data, 4, 5)
+	assert.Error(t, err)
+	_, err = NewBufferFromAt(data, 4, -1)
+	assert.Error(t, err)
+}
+
 // TestStatusAndCapacity tests buffer status queries and capacity management.
 func TestStatusAndCapacity(t *testing.T) {
 	b := NewBuffer(20)
@@ -264,8 +293,25 @@ func TestCompact(t *testing.T) {
 	assert.Equal(t, byte(0xDD), b.data[1])
 	assert.Equal(t, byte(0xEE), b.data[2])
 
+	// Marks move with the data
+	b.Seek(2)
+	b.MarkNamed("tail")
+	b.Seek(1)
+	b.Mark()
+	b.MarkNamed("head")
+	b.Compact()
+	assert.NoError(t, b.SeekMark("tail"))
+	assert.Equal(t, 1, b.Pos())
+	assert.Equal(t, byte(0xEE), b.data[1])
+	assert.NoError(t, b.SeekMark("head"))
+	assert.Equal(t, 0, b.Pos())
+	b.Seek(2)
+	assert.NoError(t, b.ResetToMark())
+	assert.Equal(t, 0, b.Pos())
+	assert.Equal(t, 2, b.Count())
+
 	// Case 3: pos = count (should clear)
-	b.Seek(3)
+	b.Seek(2)
 	b.Compact()
 	assert.Equal(t, 0, b.Pos())
 	assert.Equal(t, 0, b.Count())
@@ -337,6 +383,94 @@ func TestWrite(t *testing.T) {
 	assert.Equal(t, 10, b.Pos()) // Position should advance to 10
 }
 
+// TestWriteAt tests writing at an absolute offset.
+func TestWriteAt(t *testing.T) {
+	b := NewBuffer(8)
+	b.PutU16(0x0102)
+	n, err := b.WriteAt([]byte{0xAA, 0xBB}, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, 2, b.Pos())
+	assert.Equal(t, []byte{1, 2, 0, 0, 0xAA, 0xBB}, b.Bytes())
+
+	n, err = b.WriteAt([]byte{1, 2, 3}, 6)
+	assert.Equal(t, io.ErrShortWrite, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, 8, b.Count())
+
+	_, err = b.WriteAt([]byte{1}, -1)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	_, err = b.WriteAt(nil, 9)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+
+	bb := NewBuilder(0)
+	bb.PutU8(1)
+	n, err = bb.WriteAt([]byte{2, 3}, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, 1, bb.Pos())
+	assert.Equal(t, []byte{1, 0, 0, 2, 3}, bb.Bytes())
+	_, err = bb.WriteAt([]byte{1}, -1)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+}
+
+// TestNilSlices tests that nil and empty slices are no-ops across the array
+// methods of Buffer and Builder.
+func TestNilSlices(t *testing.T) {
+	full := NewBuffer(4)
+	full.PutU32(0x01020304)
+	full.Seek(2)
+	bb := NewBuilder(0)
+	bb.PutU16(0x0102)
+	bb.Seek(1)
+
+	for _, b := range []interface {
+		Write(p []byte) (int, error)
+		WriteAt(p []byte, off int64) (int, error)
+		PutArr8(v []byte)
+		PutArr16(v []uint16)
+		PutArr32(v []uint32)
+		PutArr64(v []uint64)
+		Bytes() []byte
+		Pos() int
+	}{full, bb} {
+		before := bytes.Clone(b.Bytes())
+		pos := b.Pos()
+		for _, p := range [][]byte{nil, {}} {
+			n, err := b.Write(p)
+			assert.NoError(t, err)
+			assert.Equal(t, 0, n)
+			n, err = b.WriteAt(p, int64(len(before)))
+			assert.NoError(t, err)
+			assert.Equal(t, 0, n)
+			b.PutArr8(p)
+		}
+		b.PutArr16(nil)
+		b.PutArr32(nil)
+		b.PutArr64(nil)
+		b.PutArr16([]uint16{})
+		b.PutArr32([]uint32{})
+		b.PutArr64([]uint64{})
+		assert.Equal(t, before, b.Bytes())
+		assert.Equal(t, pos, b.Pos())
+	}
+
+	// Offsets are still validated for empty slices
+	for _, end := range []int{0, 2} {
+		full.OverwriteArr8(end+2, nil)
+		full.OverwriteArr16(end+2, nil)
+		full.OverwriteArr32(end+2, []uint32{})
+		full.OverwriteArr64(end+2, nil)
+		full.PeekArr8(end, nil)
+		full.PeekArr16(end, []uint16{})
+		full.PeekArr32(end, nil)
+		full.PeekArr64(end, nil)
+	}
+	assert.Equal(t, []byte{1, 2, 3, 4}, full.Bytes())
+	assert.Panics(t, func() { full.OverwriteArr8(5, nil) })
+	assert.Panics(t, func() { full.PeekArr16(3, nil) })
+}
+
 // TestFill tests the Fill method of the Buffer.
 func TestFill(t *testing.T) {
 	b := NewBuffer(10)
@@ -483,3 +617,404 @@ func TestCommitAndSince(t *testing.T) {
 		assert.Equal(t, []byte{0x03, 0x04, 0x05, 0x06, 0x07, 0x08}, s.Bytes())
 	})
 }
+
+// TestStrictMode tests recording bounds violations as a sticky error.
+func TestStrictMode(t *testing.T) {
+	b := NewBuffer(4)
+	b.SetStrictMode(true)
+	assert.NoError(t, b.Err())
+
+	b.PutU16(0x0102)
+	assert.NotPanics(t, func() { b.PutU32(0x03040506) })
+	assert.Error(t, b.Err())
+	assert.Equal(t, 2, b.Pos())
+	assert.Equal(t, 2, b.Count())
+
+	// Operations are no-ops while the error is set
+	first := b.Err()
+	b.PutU8(0x03)
+	assert.Equal(t, 2, b.Count())
+	assert.Equal(t, uint8(0), b.PeekAbsU8(0))
+	b.Rewind()
+	assert.Equal(t, uint16(0), b.TakeU16())
+	assert.Equal(t, 0, b.Pos())
+	assert.Same(t, first, b.Err())
+
+	// Clearing the error resumes operations
+	b.ClearErr()
+	assert.Equal(t, uint16(0x0102), b.TakeU16())
+	assert.Equal(t, uint8(0), b.TakeU8())
+	assert.Error(t, b.Err())
+
+	b.ClearErr()
+	out := make([]uint16, 2)
+	b.Rewind()
+	b.PeekArr16(0, out)
+	assert.Error(t, b.Err())
+	b.ClearErr()
+	b.OverwriteU32(0, 0)
+	assert.Error(t, b.Err())
+	b.ClearErr()
+	b.PatchU64(0, 0)
+	assert.Error(t, b.Err())
+
+	// Views inherit the mode but not the error
+	v := b.Since(0, -1)
+	assert.NoError(t, v.Err())
+	assert.NotPanics(t, func() { v.TakeU64() })
+	assert.Error(t, v.Err())
+
+	// Default mode still panics
+	b.SetStrictMode(false)
+	b.ClearErr()
+	assert.Panics(t, func() { b.TakeU64() })
+}
+
+// TestDiff tests locating the first difference between two buffers.
+func TestDiff(t *testing.T) {
+	a := NewBufferFrom([]byte{1, 2, 3, 4})
+	b := NewBuffer(16)
+	b.PutArr8([]byte{1, 2, 3, 4})
+
+	offset, equal := a.Diff(b)
+	assert.True(t, equal)
+	assert.Equal(t, -1, offset)
+
+	b.OverwriteU8(2, 9)
+	offset, equal = a.Diff(b)
+	assert.False(t, equal)
+	assert.Equal(t, 2, offset)
+
+	// Length divergence is reported at the shorter length
+	b.OverwriteU8(2, 3)
+	b.PutU8(5)
+	offset, equal = a.Diff(b)
+	assert.False(t, equal)
+	assert.Equal(t, 4, offset)
+	offset, equal = b.Diff(a)
+	assert.False(t, equal)
+	assert.Equal(t, 4, offset)
+
+	offset, equal = NewBuffer(0).Diff(NewBuffer(8))
+	assert.True(t, equal)
+	assert.Equal(t, -1, offset)
+}
+
+// TestAlignment tests alignment queries on the current position.
+func TestAlignment(t *testing.T) {
+	b := NewBuffer(16)
+	assert.True(t, b.IsAligned(4))
+	assert.Equal(t, 0, b.Misalignment(4))
+
+	b.PutU8(0x01)
+	assert.False(t, b.IsAligned(4))
+	assert.Equal(t, 3, b.Misalignment(4))
+	assert.Equal(t, 7, b.Misalignment(8))
+	assert.True(t, b.IsAligned(1))
+
+	b.PutU16(0x0203)
+	assert.Equal(t, 1, b.Misalignment(4))
+	b.PutU8(0x04)
+	assert.True(t, b.IsAligned(4))
+	assert.False(t, b.IsAligned(8))
+
+	assert.Panics(t, func() { b.IsAligned(0) })
+	assert.Panics(t, func() { b.Misalignment(-4) })
+}
+
+// TestString tests that String covers all valid data, including read bytes.
+func TestString(t *testing.T) {
+	var _ fmt.Stringer = (*Buffer)(nil)
+
+	b := NewBufferFrom([]byte("abc"))
+	assert.Equal(t, "abc", b.String())
+	b.TakeU8()
+	assert.Equal(t, "abc", b.String())
+	assert.Equal(t, []byte("bc"), b.ReadableBytes())
+	assert.Equal(t, "", NewBuffer(4).String())
+}
+
+// TestTruncateAndReadByte tests bytes.Buffer-style helpers on Buffer.
+func TestTruncateAndReadByte(t *testing.T) {
+	b := NewBufferFrom([]byte{1, 2, 3, 4})
+	assert.Equal(t, 4, b.Len())
+	assert.Equal(t, 4, b.Cap())
+
+	c, err := b.ReadByte()
+	assert.NoError(t, err)
+	assert.Equal(t, byte(1), c)
+	assert.Equal(t, 4, b.Len()) // read bytes are included
+	assert.Equal(t, 3, b.Readable())
+
+	b.Seek(3)
+	b.Truncate(2)
+	assert.Equal(t, []byte{1, 2}, b.Bytes())
+	assert.Equal(t, 2, b.Pos())
+	assert.Equal(t, 4, b.Capacity())
+	b.Seek(1)
+	b.Truncate(2) // a position before n is kept
+	assert.Equal(t, 1, b.Pos())
+	b.Seek(2)
+	_, err = b.ReadByte()
+	assert.Equal(t, io.EOF, err)
+
+	assert.Panics(t, func() { b.Truncate(3) })
+	assert.Panics(t, func() { b.Truncate(-1) })
+
+	b.Reset()
+	assert.True(t, b.IsEmpty())
+	assert.Equal(t, 0, b.Pos())
+}
+
+// TestWindowBytes tests the look-behind window over processed data.
+func TestWindowBytes(t *testing.T) {
+	b := NewBufferFrom([]byte{1, 2, 3, 4, 5, 6})
+	assert.Equal(t, []byte{1, 2, 3, 4, 5, 6}, b.WindowBytes(2))
+
+	b.Skip(4)
+	assert.Equal(t, []byte{3, 4, 5, 6}, b.WindowBytes(2))
+	assert.Equal(t, []byte{5, 6}, b.WindowBytes(0))
+	assert.Equal(t, []byte{1, 2, 3, 4, 5, 6}, b.WindowBytes(10))
+
+	// Compact drops the processed bytes from the window
+	b.Compact()
+	assert.Equal(t, []byte{5, 6}, b.WindowBytes(2))
+
+	assert.Panics(t, func() { b.WindowBytes(-1) })
+}
+
+// TestReadEOF tests reporting io.EOF together with the final bytes.
+func TestReadEOF(t *testing.T) {
+	b := NewBufferFrom([]byte{1, 2, 3, 4, 5})
+	p := make([]byte, 3)
+
+	n, err := b.ReadEOF(p)
+	assert.Equal(t, 3, n)
+	assert.NoError(t, err)
+
+	n, err = b.ReadEOF(p)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, []byte{4, 5}, p[:n])
+
+	n, err = b.ReadEOF(p)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, io.EOF, err)
+
+	// An exact-size read also carries io.EOF
+	b.Rewind()
+	n, err = b.ReadEOF(make([]byte, 5))
+	assert.Equal(t, 5, n)
+	assert.Equal(t, io.EOF, err)
+
+	// Empty reads do not report io.EOF unless exhausted
+	b.Rewind()
+	n, err = b.ReadEOF(nil)
+	assert.Equal(t, 0, n)
+	assert.NoError(t, err)
+}
+
+// TestProcessed tests accessors for the processed region.
+func TestProcessed(t *testing.T) {
+	b := NewBufferFrom([]byte{1, 2, 3, 4, 5})
+	assert.Equal(t, []byte{}, b.ProcessedBytes())
+
+	b.Skip(3)
+	assert.Equal(t, []byte{1, 2, 3}, b.ProcessedBytes())
+
+	v := b.ProcessedSince()
+	assert.Equal(t, 3, v.Count())
+	assert.Equal(t, 0, v.Pos())
+	assert.Equal(t, uint8(1), v.TakeU8())
+
+	// Views alias the buffer
+	b.OverwriteU8(0, 9)
+	assert.Equal(t, byte(9), b.ProcessedBytes()[0])
+	assert.Equal(t, byte(9), v.Bytes()[0])
+}
+
+// TestSkipOrErr tests strict skipping.
+func TestSkipOrErr(t *testing.T) {
+	b := NewBufferFrom([]byte{1, 2, 3, 4})
+
+	n, err := b.SkipOrErr(3)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	n, err = b.SkipOrErr(-1)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	assert.Equal(t, 0, n)
+	n, err = b.SkipOrErr(2)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, 3, b.Pos())
+
+	n, err = b.SkipOrErr(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 0, b.Readable())
+}
+
+// TestWriteTo tests io.WriterTo and the io.Copy fast path.
+func TestWriteTo(t *testing.T) {
+	var _ io.WriterTo = (*Buffer)(nil)
+
+	src := NewBufferFrom([]byte{1, 2, 3, 4, 5})
+	src.Skip(1)
+	dst := NewBuilder(0)
+	dst.PutU8(0xFF)
+
+	n, err := io.Copy(dst, src)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), n)
+	assert.Equal(t, []byte{0xFF, 2, 3, 4, 5}, dst.Bytes())
+	assert.Equal(t, 0, src.Readable())
+
+	// Nothing left to copy
+	n, err = io.Copy(dst, src)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+
+	// Short writes into a fixed buffer are reported
+	src.Rewind()
+	small := NewBuffer(3)
+	n, err = src.WriteTo(small)
+	assert.Equal(t, io.ErrShortWrite, err)
+	assert.Equal(t, int64(3), n)
+	assert.Equal(t, 3, src.Pos())
+}
+
+// TestContentEqual tests comparing valid data only.
+func TestContentEqual(t *testing.T) {
+	a := NewBuffer(4)
+	a.SetEndian(LittleEndian)
+	a.PutU16(0x0201)
+
+	b := NewBuilder(64)
+	b.PutU8(0x01)
+	b.PutU8(0x02)
+	b.Rewind()
+
+	assert.True(t, ContentEqual(a, &b.Buffer))
+	b.Seek(2)
+	b.PutU8(0x03)
+	assert.False(t, ContentEqual(a, &b.Buffer))
+	assert.True(t, ContentEqual(NewBuffer(0), NewBufferFrom(nil)))
+}
+
+// TestSharesStorage tests aliasing detection between buffers.
+func TestSharesStorage(t *testing.T) {
+	a := NewBuffer(16)
+	a.PutArr8([]byte{1, 2, 3, 4, 5, 6})
+	assert.True(t, a.SharesStorage(a))
+
+	view := a.Since(2, 4)
+	assert.True(t, a.SharesStorage(view))
+	assert.True(t, view.SharesStorage(a))
+
+	// A view whose capacity ends before another starts does not alias it
+	head := NewBufferFrom(a.Bytes()[0:2:2])
+	tail := a.Since(4, 6)
+	assert.False(t, head.SharesStorage(tail))
+	assert.True(t, tail.SharesStorage(a))
+
+	independent := NewBufferFrom(append([]byte(nil), a.Bytes()...))
+	assert.False(t, a.SharesStorage(independent))
+	assert.False(t, a.SharesStorage(NewBuffer(0)))
+}
+
+// TestZeroFill tests appending zero bytes without moving the position.
+func TestZeroFill(t *testing.T) {
+	b := NewBufferFrom([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}[:0])
+	b.PutU8(0x01)
+	n := b.ZeroFill(3)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, 1, b.Pos())
+	assert.Equal(t, []byte{0x01, 0x00, 0x00, 0x00}, b.Bytes())
+
+	// Non-positive lengths are no-ops
+	assert.Equal(t, 0, b.ZeroFill(0))
+	assert.Equal(t, 0, b.ZeroFill(-1))
+	assert.Equal(t, 4, b.Count())
+
+	// Limited to the appendable space
+	n = b.ZeroFill(5)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x00}, b.Bytes())
+	assert.Equal(t, 1, b.Pos())
+}
+
+// TestLimitReader tests reading a bounded sub-stream that advances the parent.
+func TestLimitReader(t *testing.T) {
+	b := NewBufferFrom([]byte{1, 2, 3, 4, 5, 6})
+	r := b.LimitReader(4)
+
+	p := make([]byte, 3)
+	n, err := r.Read(p)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, 3, b.Pos())
+
+	n, err = r.Read(p)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, byte(4), p[0])
+	assert.Equal(t, 4, b.Pos())
+
+	n, err = r.Read(p)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, uint8(5), b.TakeU8())
+
+	// io.ReadAll consumes exactly the limit
+	b.Rewind()
+	data, err := io.ReadAll(b.LimitReader(2))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 2}, data)
+	assert.Equal(t, 2, b.Pos())
+
+	// A limit beyond the readable data stops at the end of the buffer
+	data, err = io.ReadAll(b.LimitReader(100))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{3, 4, 5, 6}, data)
+
+	// Non-positive limits are immediately at EOF
+	b.Rewind()
+	_, err = b.LimitReader(0).Read(p)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 0, b.Pos())
+}
+
+// TestChunks tests splitting the valid data into fixed-size pieces.
+func TestChunks(t *testing.T) {
+	b := NewBuffer(16)
+	b.PutArr8([]byte{1, 2, 3, 4, 5, 6, 7})
+
+	chunks := b.Chunks(3)
+	assert.Len(t, chunks, 3)
+	assert.Equal(t, []byte{1, 2, 3}, chunks[0].Bytes())
+	assert.Equal(t, []byte{4, 5, 6}, chunks[1].Bytes())
+	assert.Equal(t, []byte{7}, chunks[2].Bytes())
+	assert.Equal(t, 0, chunks[1].Pos())
+	assert.Equal(t, 1, chunks[2].Capacity())
+
+	// Views alias b but cannot write past their own end
+	chunks[0].Seek(0)
+	chunks[0].PutU8(0xAA)
+	assert.Equal(t, byte(0xAA), b.Bytes()[0])
+	chunks[0].Seek(3)
+	assert.Panics(t, func() { chunks[0].PutU8(0xBB) })
+	assert.Equal(t, byte(4), b.Bytes()[3])
+
+	// Copies are independent
+	copies := b.CopyChunks(4)
+	assert.Len(t, copies, 2)
+	assert.Equal(t, []byte{0xAA, 2, 3, 4}, copies[0].Bytes())
+	assert.Equal(t, []byte{5, 6, 7}, copies[1].Bytes())
+	assert.False(t, copies[0].SharesStorage(b))
+
+	// Exact multiples and empty buffers
+	assert.Len(t, b.Chunks(7), 1)
+	assert.Nil(t, NewBuffer(4).Chunks(2))
+	assert.Panics(t, func() { b.Chunks(0) })
+}
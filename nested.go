@@ -0,0 +1,144 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// PrefixUvarint selects a uvarint length prefix for BeginNested and TakeNested.
+// Other prefix widths are fixed: 1, 2 or 4 bytes in the buffer's byte order.
+const PrefixUvarint = 0
+
+// nestedFrame is an open sub-message started by BeginNested.
+type nestedFrame struct {
+	slot  int // offset of the reserved length prefix
+	width int // prefix width, or PrefixUvarint
+}
+
+// slotLen returns the number of bytes reserved for the prefix.
+func (f nestedFrame) slotLen() int {
+	if f.width == PrefixUvarint {
+		// A one-byte slot covers short messages; EndNested widens it if needed.
+		return 1
+	}
+	return f.width
+}
+
+// checkNestedSplice records a failure if splicing [start, end) would cut
+// into the reserved prefix of an open sub-message. An insertion has
+// start == end.
+func (b *Builder) checkNestedSplice(method string, start, end int) bool {
+	if b.err != nil {
+		return false
+	}
+	for _, f := range b.nested {
+		if start < f.slot+f.slotLen() && end > f.slot {
+			return b.fail(fmt.Errorf("mbuff.Builder.%s: range [%d, %d) overlaps the length prefix of an open nested message at %d", method, start, end, f.slot))
+		}
+	}
+	return true
+}
+
+// moveNested applies move to the prefix slots of open sub-messages.
+func (b *Builder) moveNested(move func(int) int) {
+	for i := range b.nested {
+		b.nested[i].slot = move(b.nested[i].slot)
+	}
+}
+
+// checkPrefixWidth panics unless width is PrefixUvarint, 1, 2 or 4.
+func checkPrefixWidth(method string, width int) {
+	if width != PrefixUvarint && maxUintN(width) == 0 {
+		panic(fmt.Sprintf("mbuff.%s: invalid prefix width %d", method, width))
+	}
+}
+
+// BeginNested reserves a length prefix at the current position and starts a
+// sub-message; EndNested backfills the prefix with the number of bytes
+// written after it. prefixWidth is PrefixUvarint or a fixed width of 1, 2 or
+// 4 bytes. Nested calls stack. The buffer will automatically grow if necessary.
+func (b *Builder) BeginNested(prefixWidth int) {
+	checkPrefixWidth("Builder.BeginNested", prefixWidth)
+	f := nestedFrame{slot: b.pos, width: prefixWidth}
+	b.nested = append(b.nested, f)
+	b.Claim(f.slotLen())
+}
+
+// EndNested closes the innermost sub-message started by BeginNested and
+// writes its length, taken as the bytes from the end of the prefix to the
+// current position. A uvarint prefix longer than one byte shifts the
+// sub-message (and anything after it) right.
+// Returns an error if no sub-message is open, the position moved before the
+// prefix, the length does not fit a fixed-width prefix, or an error is
+// already recorded in error mode.
+func (b *Builder) EndNested() error {
+	if len(b.nested) == 0 {
+		return fmt.Errorf("mbuff.Builder.EndNested: no open nested message")
+	}
+	f := b.nested[len(b.nested)-1]
+	b.nested = b.nested[:len(b.nested)-1]
+	if b.err != nil {
+		return b.err
+	}
+
+	if f.width != PrefixUvarint {
+		n := b.pos - f.slot - f.width
+		if n < 0 || uint64(n) > maxUintN(f.width) {
+			return fmt.Errorf("mbuff.Builder.EndNested: length %d does not fit %d-byte prefix", n, f.width)
+		}
+		b.putUintN(f.slot, f.width, uint32(n))
+		return nil
+	}
+
+	n := b.pos - f.slot - 1
+	if n < 0 {
+		return fmt.Errorf("mbuff.Builder.EndNested: position %d is before the prefix at %d", b.pos, f.slot)
+	}
+	if shift := UvarintLen(uint64(n)) - 1; shift > 0 {
+		// Widen the slot like any other insertion, so that marks, the
+		// running checksum and outer frames move with the data.
+		var pad [binary.MaxVarintLen64]byte
+		b.Insert(f.slot+1, pad[:shift])
+		if b.err != nil {
+			return b.err
+		}
+	}
+	binary.PutUvarint(b.data[f.slot:], uint64(n))
+	return nil
+}
+
+// TakeNested reads a length prefix of the given width (PrefixUvarint, 1, 2 or
+// 4) and returns a zero-copy view over the sub-message that follows, then
+// advances the position past it. The position is left unchanged if the prefix
+// is malformed or the sub-message is incomplete.
+func (b *Buffer) TakeNested(prefixWidth int) *Buffer {
+	checkPrefixWidth("Buffer.TakeNested", prefixWidth)
+	var n, prefixLen int
+	if prefixWidth == PrefixUvarint {
+		if b.err != nil {
+			return nil
+		}
+		v, k := binary.Uvarint(b.data[b.pos:])
+		if k <= 0 || v > uint64(len(b.data)) {
+			b.fail(fmt.Errorf("mbuff.Buffer.TakeNested: malformed uvarint prefix at pos %d: %w", b.pos, ErrOutOfRange))
+			return nil
+		}
+		n, prefixLen = int(v), k
+	} else {
+		if !b.checkReadable(prefixWidth) {
+			return nil
+		}
+		n, prefixLen = int(b.uintN(b.pos, prefixWidth)), prefixWidth
+	}
+	if !b.checkReadable(prefixLen + n) {
+		return nil
+	}
+	start := b.pos + prefixLen
+	b.pos = start + n
+	return b.Since(start, start+n)
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"bytes"
+	"hash/crc32"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNested_Fixed tests backfilling fixed-width length prefixes.
+func TestNested_Fixed(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutU8(0xAA)
+	b.BeginNested(2)
+	b.PutU8(0x01)
+	b.BeginNested(1)
+	b.PutU16(0x0203)
+	assert.NoError(t, b.EndNested())
+	b.PutU8(0x04)
+	assert.NoError(t, b.EndNested())
+	b.PutU8(0xBB)
+	assert.Equal(t, []byte{0xAA, 0x00, 0x05, 0x01, 0x02, 0x02, 0x03, 0x04, 0xBB}, b.Bytes())
+
+	b.Rewind()
+	b.Skip(1)
+	outer := b.TakeNested(2)
+	assert.Equal(t, uint8(0xBB), b.TakeU8())
+	assert.Equal(t, uint8(0x01), outer.TakeU8())
+	inner := outer.TakeNested(1)
+	assert.Equal(t, uint16(0x0203), inner.TakeU16())
+	assert.Equal(t, 0, inner.Readable())
+	assert.Equal(t, uint8(0x04), outer.TakeU8())
+
+	// Overflowing prefix and unbalanced calls
+	b.Clear()
+	b.BeginNested(1)
+	b.PutArr8(make([]byte, 256))
+	assert.Error(t, b.EndNested())
+	assert.Error(t, b.EndNested())
+	assert.Panics(t, func() { b.BeginNested(3) })
+}
+
+// TestNested_Uvarint tests widening a uvarint prefix on backfill.
+func TestNested_Uvarint(t *testing.T) {
+	payload := bytes.Repeat([]byte{0x55}, 300)
+
+	b := NewBuilder(0)
+	b.BeginNested(PrefixUvarint)
+	b.PutU8(0x01)
+	b.BeginNested(PrefixUvarint)
+	b.PutArr8(payload)
+	assert.NoError(t, b.EndNested())
+	b.PutU8(0x02)
+	assert.NoError(t, b.EndNested())
+
+	// inner: 2-byte prefix + 300, outer: 1 + 302 + 1 = 304 -> 2-byte prefix
+	assert.Equal(t, 2+1+2+300+1, b.Count())
+	assert.Equal(t, b.Count(), b.Pos())
+	assert.Equal(t, []byte{0xB0, 0x02, 0x01, 0xAC, 0x02}, b.Bytes()[:5])
+
+	b.Rewind()
+	outer := b.TakeNested(PrefixUvarint)
+	assert.Equal(t, 0, b.Readable())
+	assert.Equal(t, uint8(0x01), outer.TakeU8())
+	inner := outer.TakeNested(PrefixUvarint)
+	assert.Equal(t, payload, inner.Bytes())
+	assert.Equal(t, uint8(0x02), outer.TakeU8())
+
+	// Truncated sub-message leaves pos unchanged
+	r := NewBufferFrom([]byte{0x05, 0x01})
+	assert.Panics(t, func() { r.TakeNested(PrefixUvarint) })
+	r.SetStrictMode(true)
+	assert.Nil(t, r.TakeNested(1))
+	assert.ErrorIs(t, r.Err(), ErrOutOfRange)
+	assert.Equal(t, 0, r.Pos())
+}
+
+// TestNested_Splice tests that splices move open sub-messages with their bytes.
+func TestNested_Splice(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutU8(0xAA)
+	b.BeginNested(1)
+	b.PutArr8([]byte{1, 2, 3})
+	b.Insert(0, []byte{0xEE, 0xEE})
+	assert.NoError(t, b.EndNested())
+	assert.Equal(t, []byte{0xEE, 0xEE, 0xAA, 0x03, 0x01, 0x02, 0x03}, b.Bytes())
+
+	b.Clear()
+	b.PutArr8([]byte{0xEE, 0xEE})
+	b.BeginNested(2)
+	b.PutArr8([]byte{1, 2, 3})
+	b.Delete(0, 2)
+	assert.NoError(t, b.EndNested())
+	assert.Equal(t, []byte{0x00, 0x03, 0x01, 0x02, 0x03}, b.Bytes())
+
+	// Splicing into an open prefix is rejected
+	b = NewBuilder(0)
+	b.BeginNested(2)
+	b.PutU8(0x01)
+	assert.Panics(t, func() { b.Insert(1, []byte{0xEE}) })
+	assert.Panics(t, func() { b.Delete(0, 1) })
+	b.SetStrictMode(true)
+	b.Delete(1, 2)
+	assert.Error(t, b.Err())
+	assert.Equal(t, []byte{0x00, 0x00, 0x01}, b.Bytes())
+}
+
+// TestNested_UvarintWidenPositions tests that widening a uvarint prefix moves
+// marks and the running checksum with the data.
+func TestNested_UvarintWidenPositions(t *testing.T) {
+	payload := bytes.Repeat([]byte{0x55}, 200)
+
+	b := NewBuilder(0)
+	b.EnableRunningChecksum(crc32.NewIEEE())
+	b.BeginNested(PrefixUvarint)
+	b.PutArr8(payload[:100])
+	b.MarkNamed("mid")
+	b.PutArr8(payload[100:])
+	assert.NoError(t, b.EndNested())
+	assert.Equal(t, 2+200, b.Count())
+	// The slot was hashed once, as a placeholder, before it was widened
+	assert.Equal(t, crc32.ChecksumIEEE(append([]byte{0x00}, payload...)), b.RunningChecksum())
+
+	assert.NoError(t, b.SeekMark("mid"))
+	assert.Equal(t, 2+100, b.Pos())
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"fmt"
+)
+
+// TakeOneof reads a tagged union: a uint8 tag selecting one of cases,
+// followed by the payload that the selected case decodes from b. It returns
+// the tag and the decoded value, then leaves the position after the payload.
+// An unknown tag or a decoding error is returned with the position restored
+// to the tag.
+func (b *Buffer) TakeOneof(cases map[uint8]func(b *Buffer) (any, error)) (uint8, any, error) {
+	if !b.checkReadable(1) {
+		return 0, nil, b.err
+	}
+	start := b.pos
+	tag := b.data[start]
+	decode, ok := cases[tag]
+	if !ok {
+		return tag, nil, fmt.Errorf("mbuff.Buffer.TakeOneof: unknown tag %d at pos %d", tag, start)
+	}
+	b.pos++
+	v, err := decode(b)
+	if err == nil {
+		err = b.err
+	}
+	if err != nil {
+		b.setPos(start)
+		return tag, nil, err
+	}
+	return tag, v, nil
+}
+
+// PutOneof writes v as a tagged union: the uint8 tag followed by the payload
+// that cases[tag] encodes, then advances the position. An unknown tag is an
+// error and nothing is written; an error from the case is returned as is,
+// after the tag and any partial payload have been written.
+// The buffer will automatically grow if necessary.
+func (b *Builder) PutOneof(tag uint8, cases map[uint8]func(b *Builder, v any) error, v any) error {
+	encode, ok := cases[tag]
+	if !ok {
+		return fmt.Errorf("mbuff.Builder.PutOneof: unknown tag %d", tag)
+	}
+	b.PutU8(tag)
+	if err := encode(b, v); err != nil {
+		return err
+	}
+	return b.err
+}
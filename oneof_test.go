@@ -0,0 +1,92 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Tags of the test union.
+const (
+	tagCount uint8 = 1
+	tagName  uint8 = 2
+)
+
+var oneofEncoders = map[uint8]func(*Builder, any) error{
+	tagCount: func(b *Builder, v any) error {
+		n, ok := v.(uint32)
+		if !ok {
+			return fmt.Errorf("count: unexpected %T", v)
+		}
+		b.PutU32(n)
+		return nil
+	},
+	tagName: func(b *Builder, v any) error {
+		b.PutSizedBytes([]byte(v.(string)))
+		return nil
+	},
+}
+
+var oneofDecoders = map[uint8]func(*Buffer) (any, error){
+	tagCount: func(b *Buffer) (any, error) { return b.TakeU32(), nil },
+	tagName:  func(b *Buffer) (any, error) { return string(b.TakeSizedBytes()), nil },
+}
+
+// TestOneof tests tagged union round trips.
+func TestOneof(t *testing.T) {
+	b := NewBuilder(0)
+	assert.NoError(t, b.PutOneof(tagCount, oneofEncoders, uint32(7)))
+	assert.NoError(t, b.PutOneof(tagName, oneofEncoders, "ab"))
+	assert.Equal(t, []byte{0x01, 0x00, 0x00, 0x00, 0x07, 0x02, 0x02, 'a', 'b'}, b.Bytes())
+
+	b.Rewind()
+	tag, v, err := b.TakeOneof(oneofDecoders)
+	assert.NoError(t, err)
+	assert.Equal(t, tagCount, tag)
+	assert.Equal(t, uint32(7), v)
+	tag, v, err = b.TakeOneof(oneofDecoders)
+	assert.NoError(t, err)
+	assert.Equal(t, tagName, tag)
+	assert.Equal(t, "ab", v)
+	assert.Equal(t, 0, b.Readable())
+}
+
+// TestOneof_Errors tests unknown tags and failing cases.
+func TestOneof_Errors(t *testing.T) {
+	b := NewBuilder(0)
+	assert.ErrorContains(t, b.PutOneof(9, oneofEncoders, nil), "unknown tag 9")
+	assert.Equal(t, 0, b.Count())
+	assert.ErrorContains(t, b.PutOneof(tagCount, oneofEncoders, "x"), "unexpected string")
+
+	r := NewBufferFrom([]byte{0x09, 0x00})
+	tag, _, err := r.TakeOneof(oneofDecoders)
+	assert.Equal(t, uint8(9), tag)
+	assert.ErrorContains(t, err, "unknown tag 9")
+	assert.Equal(t, 0, r.Pos())
+
+	// Decoding errors restore the position
+	failing := map[uint8]func(*Buffer) (any, error){
+		1: func(b *Buffer) (any, error) {
+			b.TakeU8()
+			return nil, errors.New("bad payload")
+		},
+	}
+	r = NewBufferFrom([]byte{0x01, 0x02})
+	_, _, err = r.TakeOneof(failing)
+	assert.EqualError(t, err, "bad payload")
+	assert.Equal(t, 0, r.Pos())
+
+	// Truncated payloads in error mode
+	r = NewBufferFrom([]byte{0x01, 0x00})
+	r.SetStrictMode(true)
+	_, _, err = r.TakeOneof(oneofDecoders)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	assert.Equal(t, 0, r.Pos())
+}
@@ -9,48 +9,66 @@ import (
 	"fmt"
 )
 
-// mustHaveOverwritable checks if the offset and length are within the count.
-func (b *Buffer) mustHaveOverwritable(offset int, n int) {
+// checkOverwritable checks if the offset and length are within the count.
+// On success the running checksum is synced, as the caller then modifies data.
+func (b *Buffer) checkOverwritable(offset int, n int) bool {
+	if b.err != nil {
+		return false
+	}
 	if offset < 0 || offset+n > len(b.data) {
-		panic(fmt.Errorf("mbuff.Buffer.mustHaveOverwritable: overwrite at offset %d exceeds count %d", offset, len(b.data)))
+		return b.fail(fmt.Errorf("mbuff.Buffer.checkOverwritable: overwrite at offset %d exceeds count %d: %w", offset, len(b.data), ErrOutOfRange))
 	}
+	b.syncChecksum()
+	return true
 }
 
 // OverwriteU8 overwrites a uint8 at the specified offset.
 func (b *Buffer) OverwriteU8(offset int, v uint8) {
-	b.mustHaveOverwritable(offset, 1)
+	if !b.checkOverwritable(offset, 1) {
+		return
+	}
 	b.data[offset] = v
 }
 
 // OverwriteU16 overwrites a uint16 at the specified offset.
 func (b *Buffer) OverwriteU16(offset int, v uint16) {
-	b.mustHaveOverwritable(offset, 2)
+	if !b.checkOverwritable(offset, 2) {
+		return
+	}
 	b.order.PutUint16(b.data[offset:offset+2], v)
 }
 
 // OverwriteU32 overwrites a uint32 at the specified offset.
 func (b *Buffer) OverwriteU32(offset int, v uint32) {
-	b.mustHaveOverwritable(offset, 4)
+	if !b.checkOverwritable(offset, 4) {
+		return
+	}
 	b.order.PutUint32(b.data[offset:offset+4], b.HLSwap32(v))
 }
 
 // OverwriteU64 overwrites a uint64 at the specified offset.
 func (b *Buffer) OverwriteU64(offset int, v uint64) {
-	b.mustHaveOverwritable(offset, 8)
+	if !b.checkOverwritable(offset, 8) {
+		return
+	}
 	b.order.PutUint64(b.data[offset:offset+8], b.HLSwap64(v))
 }
 
 // OverwriteArr8 overwrites bytes at the specified offset with slice v.
 func (b *Buffer) OverwriteArr8(offset int, v []byte) {
 	byteLen := len(v)
-	b.mustHaveOverwritable(offset, byteLen)
+	if !b.checkOverwritable(offset, byteLen) {
+		return
+	}
 	copy(b.data[offset:offset+byteLen], v)
 }
 
 // OverwriteArr16 overwrites uint16 values at the specified offset with slice v.
 func (b *Buffer) OverwriteArr16(offset int, v []uint16) {
 	byteLen := len(v) << 1
-	b.mustHaveOverwritable(offset, byteLen)
+	if !b.checkOverwritable(offset, byteLen) {
+		return
+	}
 	writePos := offset
 	for _, val := range v {
 		b.order.PutUint16(b.data[writePos:writePos+2], val)
@@ -61,7 +79,9 @@ func (b *Buffer) OverwriteArr16(offset int, v []uint16) {
 // OverwriteArr32 overwrites uint32 values at the specified offset with slice v.
 func (b *Buffer) OverwriteArr32(offset int, v []uint32) {
 	byteLen := len(v) << 2
-	b.mustHaveOverwritable(offset, byteLen)
+	if !b.checkOverwritable(offset, byteLen) {
+		return
+	}
 	writePos := offset
 	for _, val := range v {
 		b.order.PutUint32(b.data[writePos:writePos+4], b.HLSwap32(val))
@@ -72,7 +92,111 @@ func (b *Buffer) OverwriteArr32(offset int, v []uint32) {
 // OverwriteArr64 overwrites uint64 values at the specified offset with slice v.
 func (b *Buffer) OverwriteArr64(offset int, v []uint64) {
 	byteLen := len(v) << 3
-	b.mustHaveOverwritable(offset, byteLen)
+	if !b.checkOverwritable(offset, byteLen) {
+		return
+	}
+	writePos := offset
+	for _, val := range v {
+		b.order.PutUint64(b.data[writePos:writePos+8], b.HLSwap64(val))
+		writePos += 8
+	}
+}
+
+// checkPatchable checks if the offset and length are within the capacity,
+// then extends the count to offset+n if needed, zero-filling any gap.
+// On success the running checksum is synced, as the caller then modifies data.
+func (b *Buffer) checkPatchable(offset int, n int) bool {
+	if b.err != nil {
+		return false
+	}
+	if offset < 0 || offset+n > cap(b.data) {
+		return b.fail(fmt.Errorf("mbuff.Buffer.checkPatchable: patch at offset %d exceeds capacity %d: %w", offset, cap(b.data), ErrOutOfRange))
+	}
+	required := offset + n
+	if required > len(b.data) {
+		count := len(b.data)
+		b.data = b.data[:required]
+		if offset > count {
+			clear(b.data[count:offset])
+		}
+	}
+	b.syncChecksum()
+	return true
+}
+
+// PatchU8 writes a uint8 at the specified offset, extending the count if needed.
+func (b *Buffer) PatchU8(offset int, v uint8) {
+	if !b.checkPatchable(offset, 1) {
+		return
+	}
+	b.data[offset] = v
+}
+
+// PatchU16 writes a uint16 at the specified offset, extending the count if needed.
+func (b *Buffer) PatchU16(offset int, v uint16) {
+	if !b.checkPatchable(offset, 2) {
+		return
+	}
+	b.order.PutUint16(b.data[offset:offset+2], v)
+}
+
+// PatchU32 writes a uint32 at the specified offset, extending the count if needed.
+func (b *Buffer) PatchU32(offset int, v uint32) {
+	if !b.checkPatchable(offset, 4) {
+		return
+	}
+	b.order.PutUint32(b.data[offset:offset+4], b.HLSwap32(v))
+}
+
+// PatchU64 writes a uint64 at the specified offset, extending the count if needed.
+func (b *Buffer) PatchU64(offset int, v uint64) {
+	if !b.checkPatchable(offset, 8) {
+		return
+	}
+	b.order.PutUint64(b.data[offset:offset+8], b.HLSwap64(v))
+}
+
+// PatchArr8 writes bytes at the specified offset, extending the count if needed.
+func (b *Buffer) PatchArr8(offset int, v []byte) {
+	byteLen := len(v)
+	if !b.checkPatchable(offset, byteLen) {
+		return
+	}
+	copy(b.data[offset:offset+byteLen], v)
+}
+
+// PatchArr16 writes uint16 values at the specified offset, extending the count if needed.
+func (b *Buffer) PatchArr16(offset int, v []uint16) {
+	byteLen := len(v) << 1
+	if !b.checkPatchable(offset, byteLen) {
+		return
+	}
+	writePos := offset
+	for _, val := range v {
+		b.order.PutUint16(b.data[writePos:writePos+2], val)
+		writePos += 2
+	}
+}
+
+// PatchArr32 writes uint32 values at the specified offset, extending the count if needed.
+func (b *Buffer) PatchArr32(offset int, v []uint32) {
+	byteLen := len(v) << 2
+	if !b.checkPatchable(offset, byteLen) {
+		return
+	}
+	writePos := offset
+	for _, val := range v {
+		b.order.PutUint32(b.data[writePos:writePos+4], b.HLSwap32(val))
+		writePos += 4
+	}
+}
+
+// PatchArr64 writes uint64 values at the specified offset, extending the count if needed.
+func (b *Buffer) PatchArr64(offset int, v []uint64) {
+	byteLen := len(v) << 3
+	if !b.checkPatchable(offset, byteLen) {
+		return
+	}
 	writePos := offset
 	for _, val := range v {
 		b.order.PutUint64(b.data[writePos:writePos+8], b.HLSwap64(val))
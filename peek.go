@@ -9,52 +9,125 @@ import (
 	"fmt"
 )
 
-// mustHavePeekable checks if the offset and length are within the count.
-func (b *Buffer) mustHavePeekable(offset int, n int) int {
+// checkPeekable checks if the offset and length are within the count.
+func (b *Buffer) checkPeekable(offset int, n int) (int, bool) {
+	if b.err != nil {
+		return 0, false
+	}
 	absPos := b.pos + offset
 	if absPos < 0 || absPos+n > len(b.data) {
-		panic(fmt.Errorf("mbuff.Buffer.mustHavePeekable: peek at pos %d + offset %d exceeds count %d", b.pos, offset, len(b.data)))
+		return 0, b.fail(fmt.Errorf("mbuff.Buffer.checkPeekable: peek at pos %d + offset %d exceeds count %d: %w", b.pos, offset, len(b.data), ErrOutOfRange))
 	}
-	return absPos
+	return absPos, true
 }
 
 // PeekU8 reads a uint8 at pos+offset without advancing the position.
 func (b *Buffer) PeekU8(offset int) uint8 {
-	absPos := b.mustHavePeekable(offset, 1)
+	absPos, ok := b.checkPeekable(offset, 1)
+	if !ok {
+		return 0
+	}
 	return b.data[absPos]
 }
 
 // PeekU16 reads a uint16 at pos+offset without advancing the position.
 func (b *Buffer) PeekU16(offset int) uint16 {
-	absPos := b.mustHavePeekable(offset, 2)
+	absPos, ok := b.checkPeekable(offset, 2)
+	if !ok {
+		return 0
+	}
 	return b.order.Uint16(b.data[absPos : absPos+2])
 }
 
 // PeekU32 reads a uint32 at pos+offset without advancing the position.
 func (b *Buffer) PeekU32(offset int) uint32 {
-	absPos := b.mustHavePeekable(offset, 4)
+	absPos, ok := b.checkPeekable(offset, 4)
+	if !ok {
+		return 0
+	}
 	v := b.order.Uint32(b.data[absPos : absPos+4])
 	return b.HLSwap32(v)
 }
 
 // PeekU64 reads a uint64 at pos+offset without advancing the position.
 func (b *Buffer) PeekU64(offset int) uint64 {
-	absPos := b.mustHavePeekable(offset, 8)
+	absPos, ok := b.checkPeekable(offset, 8)
+	if !ok {
+		return 0
+	}
 	v := b.order.Uint64(b.data[absPos : absPos+8])
 	return b.HLSwap64(v)
 }
 
+// PeekU16In reads a uint16 in byte order e at pos+offset without advancing the position.
+func (b *Buffer) PeekU16In(offset int, e Endian) uint16 {
+	absPos, ok := b.checkPeekable(offset, 2)
+	if !ok {
+		return 0
+	}
+	return e.byteOrder().Uint16(b.data[absPos : absPos+2])
+}
+
+// PeekU32In reads a uint32 in byte order e at pos+offset without advancing the position.
+// High-low swap still applies.
+func (b *Buffer) PeekU32In(offset int, e Endian) uint32 {
+	absPos, ok := b.checkPeekable(offset, 4)
+	if !ok {
+		return 0
+	}
+	v := e.byteOrder().Uint32(b.data[absPos : absPos+4])
+	return b.HLSwap32(v)
+}
+
+// PeekU64In reads a uint64 in byte order e at pos+offset without advancing the position.
+// High-low swap still applies.
+func (b *Buffer) PeekU64In(offset int, e Endian) uint64 {
+	absPos, ok := b.checkPeekable(offset, 8)
+	if !ok {
+		return 0
+	}
+	v := e.byteOrder().Uint64(b.data[absPos : absPos+8])
+	return b.HLSwap64(v)
+}
+
+// PeekRune reads a UTF-8 encoded rune at pos+offset and returns it with its
+// length in bytes, without advancing the position.
+func (b *Buffer) PeekRune(offset int) (rune, int) {
+	absPos, ok := b.checkPeekable(offset, 0)
+	if !ok {
+		return 0, 0
+	}
+	return b.decodeRune("PeekRune", absPos)
+}
+
+// PeekCStr reads a NUL-terminated string at pos+offset and returns it,
+// without the terminator, with its length in bytes including the terminator.
+// The position is not advanced.
+func (b *Buffer) PeekCStr(offset int) (string, int) {
+	absPos, ok := b.checkPeekable(offset, 0)
+	if !ok {
+		return "", 0
+	}
+	return b.decodeCStr("PeekCStr", absPos)
+}
+
 // PeekArr8 reads bytes at pos+offset into slice v without advancing the position.
 func (b *Buffer) PeekArr8(offset int, v []byte) {
 	byteLen := len(v)
-	absPos := b.mustHavePeekable(offset, byteLen)
+	absPos, ok := b.checkPeekable(offset, byteLen)
+	if !ok {
+		return
+	}
 	copy(v, b.data[absPos:absPos+byteLen])
 }
 
 // PeekArr16 reads uint16 values at pos+offset into slice v without advancing the position.
 func (b *Buffer) PeekArr16(offset int, v []uint16) {
 	byteLen := len(v) << 1
-	absPos := b.mustHavePeekable(offset, byteLen)
+	absPos, ok := b.checkPeekable(offset, byteLen)
+	if !ok {
+		return
+	}
 	readPos := absPos
 	for i := range v {
 		v[i] = b.order.Uint16(b.data[readPos : readPos+2])
@@ -62,10 +135,37 @@ func (b *Buffer) PeekArr16(offset int, v []uint16) {
 	}
 }
 
+// PeekArr16Stride reads every stride-th uint16 starting at pos+offset into
+// slice v without advancing the position, i.e. v[i] is the element at byte
+// pos+offset+i*stride*2. Only the span up to the last accessed element must
+// be readable. Panics if stride is not positive.
+func (b *Buffer) PeekArr16Stride(offset int, stride int, v []uint16) {
+	if stride <= 0 {
+		panic("mbuff.Buffer.PeekArr16Stride: non-positive stride")
+	}
+	if len(v) == 0 {
+		return
+	}
+	step := stride << 1
+	byteLen := (len(v)-1)*step + 2
+	absPos, ok := b.checkPeekable(offset, byteLen)
+	if !ok {
+		return
+	}
+	readPos := absPos
+	for i := range v {
+		v[i] = b.order.Uint16(b.data[readPos : readPos+2])
+		readPos += step
+	}
+}
+
 // PeekArr32 reads uint32 values at pos+offset into slice v without advancing the position.
 func (b *Buffer) PeekArr32(offset int, v []uint32) {
 	byteLen := len(v) << 2
-	absPos := b.mustHavePeekable(offset, byteLen)
+	absPos, ok := b.checkPeekable(offset, byteLen)
+	if !ok {
+		return
+	}
 	readPos := absPos
 	for i := range v {
 		val := b.order.Uint32(b.data[readPos : readPos+4])
@@ -77,7 +177,10 @@ func (b *Buffer) PeekArr32(offset int, v []uint32) {
 // PeekArr64 reads uint64 values at pos+offset into slice v without advancing the position.
 func (b *Buffer) PeekArr64(offset int, v []uint64) {
 	byteLen := len(v) << 3
-	absPos := b.mustHavePeekable(offset, byteLen)
+	absPos, ok := b.checkPeekable(offset, byteLen)
+	if !ok {
+		return
+	}
 	readPos := absPos
 	for i := range v {
 		val := b.order.Uint64(b.data[readPos : readPos+8])
@@ -85,3 +188,104 @@ func (b *Buffer) PeekArr64(offset int, v []uint64) {
 		readPos += 8
 	}
 }
+
+// checkAbsPeekable checks if the absolute offset and length are within the count.
+func (b *Buffer) checkAbsPeekable(absOffset int, n int) bool {
+	if b.err != nil {
+		return false
+	}
+	if absOffset < 0 || absOffset+n > len(b.data) {
+		return b.fail(fmt.Errorf("mbuff.Buffer.checkAbsPeekable: peek at absolute offset %d exceeds count %d: %w", absOffset, len(b.data), ErrOutOfRange))
+	}
+	return true
+}
+
+// PeekAbsU8 reads a uint8 at the absolute offset absOffset, independent of the position.
+func (b *Buffer) PeekAbsU8(absOffset int) uint8 {
+	if !b.checkAbsPeekable(absOffset, 1) {
+		return 0
+	}
+	return b.data[absOffset]
+}
+
+// PeekAbsU16 reads a uint16 at the absolute offset absOffset, independent of the position.
+func (b *Buffer) PeekAbsU16(absOffset int) uint16 {
+	if !b.checkAbsPeekable(absOffset, 2) {
+		return 0
+	}
+	return b.order.Uint16(b.data[absOffset : absOffset+2])
+}
+
+// PeekAbsU32 reads a uint32 at the absolute offset absOffset, independent of the position.
+func (b *Buffer) PeekAbsU32(absOffset int) uint32 {
+	if !b.checkAbsPeekable(absOffset, 4) {
+		return 0
+	}
+	v := b.order.Uint32(b.data[absOffset : absOffset+4])
+	return b.HLSwap32(v)
+}
+
+// PeekAbsU64 reads a uint64 at the absolute offset absOffset, independent of the position.
+func (b *Buffer) PeekAbsU64(absOffset int) uint64 {
+	if !b.checkAbsPeekable(absOffset, 8) {
+		return 0
+	}
+	v := b.order.Uint64(b.data[absOffset : absOffset+8])
+	return b.HLSwap64(v)
+}
+
+// peekableRange checks if the offset and length are within the count,
+// returning an error instead of panicking regardless of the failure mode.
+func (b *Buffer) peekableRange(method string, offset int, n int) (int, error) {
+	absPos := b.pos + offset
+	if absPos < 0 || absPos+n > len(b.data) {
+		return 0, fmt.Errorf("mbuff.Buffer.%s: peek at pos %d + offset %d exceeds count %d: %w", method, b.pos, offset, len(b.data), ErrOutOfRange)
+	}
+	return absPos, nil
+}
+
+// TryPeekU8 reads a uint8 at pos+offset without advancing the position.
+// It returns an error wrapping ErrOutOfRange instead of panicking, and does
+// not record it in error mode.
+func (b *Buffer) TryPeekU8(offset int) (uint8, error) {
+	absPos, err := b.peekableRange("TryPeekU8", offset, 1)
+	if err != nil {
+		return 0, err
+	}
+	return b.data[absPos], nil
+}
+
+// TryPeekU16 reads a uint16 at pos+offset without advancing the position.
+// It returns an error wrapping ErrOutOfRange instead of panicking, and does
+// not record it in error mode.
+func (b *Buffer) TryPeekU16(offset int) (uint16, error) {
+	absPos, err := b.peekableRange("TryPeekU16", offset, 2)
+	if err != nil {
+		return 0, err
+	}
+	return b.order.Uint16(b.data[absPos : absPos+2]), nil
+}
+
+// TryPeekU32 reads a uint32 at pos+offset without advancing the position.
+// It returns an error wrapping ErrOutOfRange instead of panicking, and does
+// not record it in error mode.
+func (b *Buffer) TryPeekU32(offset int) (uint32, error) {
+	absPos, err := b.peekableRange("TryPeekU32", offset, 4)
+	if err != nil {
+		return 0, err
+	}
+	v := b.order.Uint32(b.data[absPos : absPos+4])
+	return b.HLSwap32(v), nil
+}
+
+// TryPeekU64 reads a uint64 at pos+offset without advancing the position.
+// It returns an error wrapping ErrOutOfRange instead of panicking, and does
+// not record it in error mode.
+func (b *Buffer) TryPeekU64(offset int) (uint64, error) {
+	absPos, err := b.peekableRange("TryPeekU64", offset, 8)
+	if err != nil {
+		return 0, err
+	}
+	v := b.order.Uint64(b.data[absPos : absPos+8])
+	return b.HLSwap64(v), nil
+}
@@ -155,3 +155,131 @@ func TestOverwriteArr(t *testing.T) {
 	assert.Equal(t, uint32(0xCAFEBABE), b.TakeU32())
 	assert.Equal(t, uint64(0xDEADBEEFCAFEBABE), b.TakeU64())
 }
+
+// TestPeekAbs tests peeking scalar values at absolute offsets.
+func TestPeekAbs(t *testing.T) {
+	b := NewBuffer(30)
+	b.PutU8(0x01)
+	b.PutU16(0x0203)
+	b.PutU32(0x04050607)
+	b.PutU64(0x08090A0B0C0D0E0F)
+	b.Seek(5)
+
+	// Peek from buffer start, ignoring pos
+	assert.Equal(t, uint8(0x01), b.PeekAbsU8(0))
+	assert.Equal(t, uint16(0x0203), b.PeekAbsU16(1))
+	assert.Equal(t, uint32(0x04050607), b.PeekAbsU32(3))
+	assert.Equal(t, uint64(0x08090A0B0C0D0E0F), b.PeekAbsU64(7))
+
+	// Verify position unchanged
+	assert.Equal(t, 5, b.Pos())
+
+	// Bounds are validated against count, not capacity
+	assert.Panics(t, func() { b.PeekAbsU8(-1) })
+	assert.Panics(t, func() { b.PeekAbsU8(15) })
+	assert.Panics(t, func() { b.PeekAbsU16(14) })
+	assert.Panics(t, func() { b.PeekAbsU32(12) })
+	assert.Panics(t, func() { b.PeekAbsU64(8) })
+	assert.NotPanics(t, func() { b.PeekAbsU64(7) })
+}
+
+// TestPatch tests positioned writes that may extend the count.
+func TestPatch(t *testing.T) {
+	buf := make([]byte, 8, 16)
+	for i := range buf {
+		buf[i] = 0xFF
+	}
+	b := NewBufferFrom(buf[:2])
+	b.Seek(1)
+
+	// Within count behaves like Overwrite
+	b.PatchU8(0, 0x01)
+	assert.Equal(t, []byte{0x01, 0xFF}, b.Bytes())
+
+	// Past count extends it and zero-fills the gap
+	b.PatchU16(4, 0x0203)
+	assert.Equal(t, []byte{0x01, 0xFF, 0x00, 0x00, 0x02, 0x03}, b.Bytes())
+	b.PatchU32(6, 0x04050607)
+	b.PatchArr8(10, []byte{0x08, 0x09})
+	assert.Equal(t, 12, b.Count())
+	assert.Equal(t, 1, b.Pos())
+	assert.Equal(t, uint32(0x04050607), b.PeekAbsU32(6))
+
+	b.PatchArr16(12, []uint16{0x0A0B})
+	b.PatchArr32(12, []uint32{0x0C0D0E0F})
+	assert.Equal(t, uint32(0x0C0D0E0F), b.PeekAbsU32(12))
+	assert.Equal(t, 16, b.Count())
+
+	// Beyond capacity still panics
+	assert.Panics(t, func() { b.PatchU8(16, 0x00) })
+	assert.Panics(t, func() { b.PatchU64(9, 0x00) })
+	assert.Panics(t, func() { b.PatchU8(-1, 0x00) })
+}
+
+// TestPeekArr16Stride tests strided sampling without consuming.
+func TestPeekArr16Stride(t *testing.T) {
+	b := NewBuffer(64)
+	b.PutU8(0xFF)
+	b.PutArr16([]uint16{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+	b.Seek(1)
+
+	out := make([]uint16, 4)
+	b.PeekArr16Stride(0, 3, out)
+	assert.Equal(t, []uint16{0, 3, 6, 9}, out)
+	assert.Equal(t, 1, b.Pos())
+
+	out = make([]uint16, 3)
+	b.PeekArr16Stride(2, 4, out)
+	assert.Equal(t, []uint16{1, 5, 9}, out)
+
+	b.PeekArr16Stride(0, 1, out)
+	assert.Equal(t, []uint16{0, 1, 2}, out)
+
+	// Bounds are validated against the last accessed element
+	assert.Panics(t, func() { b.PeekArr16Stride(2, 5, out) })
+	assert.Panics(t, func() { b.PeekArr16Stride(0, 0, out) })
+	assert.NotPanics(t, func() { b.PeekArr16Stride(100, 1, nil) })
+}
+
+// TestTryPeek tests peeking that returns errors instead of panicking.
+func TestTryPeek(t *testing.T) {
+	b := NewBuffer(16)
+	b.PutU8(0x01)
+	b.PutU16(0x0203)
+	b.PutU32(0x04050607)
+	b.PutU64(0x08090A0B0C0D0E0F)
+	b.Seek(1)
+
+	v8, err := b.TryPeekU8(-1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(0x01), v8)
+	v16, err := b.TryPeekU16(0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(0x0203), v16)
+	v32, err := b.TryPeekU32(2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0x04050607), v32)
+	v64, err := b.TryPeekU64(6)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0x08090A0B0C0D0E0F), v64)
+	assert.Equal(t, 1, b.Pos())
+
+	_, err = b.TryPeekU8(-2)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	_, err = b.TryPeekU16(13)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	_, err = b.TryPeekU32(12)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	_, err = b.TryPeekU64(7)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+
+	// Try methods do not touch the sticky error
+	b.SetStrictMode(true)
+	_, err = b.TryPeekU64(7)
+	assert.Error(t, err)
+	assert.NoError(t, b.Err())
+
+	// Recorded bounds violations share the same error value
+	b.PeekU64(7)
+	assert.ErrorIs(t, b.Err(), ErrOutOfRange)
+}
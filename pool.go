@@ -0,0 +1,54 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"encoding/binary"
+	"sync"
+)
+
+// bufferPool holds backing arrays returned by Release.
+var bufferPool sync.Pool // of *[]byte
+
+// Acquire returns an empty Buffer with at least the given capacity and
+// default settings, reusing a backing array returned by Release if a large
+// enough one is available. Pair it with Release to reduce allocations for
+// short-lived buffers. Panics if capacity is negative.
+func Acquire(capacity int) *Buffer {
+	if capacity < 0 {
+		panic("mbuff.Acquire: negative capacity")
+	}
+	var data []byte
+	if p, ok := bufferPool.Get().(*[]byte); ok {
+		if cap(*p) >= capacity {
+			data = (*p)[:0]
+		} else {
+			bufferPool.Put(p) // leave it for a smaller request
+		}
+	}
+	if data == nil {
+		data = make([]byte, 0, capacity)
+	}
+	return &Buffer{
+		data:  data,
+		order: binary.BigEndian,
+	}
+}
+
+// Release clears b and returns its backing array to the pool used by
+// Acquire. The data is not zeroed. b is left empty with no capacity, and any
+// slice or view previously obtained from it may be overwritten by a later
+// user of the array, so using either after Release is undefined. Releasing a
+// nil Buffer or one without capacity is a no-op.
+func Release(b *Buffer) {
+	if b == nil || cap(b.data) == 0 {
+		return
+	}
+	b.Clear()
+	data := b.data
+	b.data = nil
+	bufferPool.Put(&data)
+}
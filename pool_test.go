@@ -0,0 +1,41 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAcquireRelease tests reusing backing arrays through the pool.
+func TestAcquireRelease(t *testing.T) {
+	b := Acquire(64)
+	assert.GreaterOrEqual(t, b.Capacity(), 64)
+	assert.Equal(t, 0, b.Count())
+	assert.Equal(t, BigEndian, b.GetEndian())
+	b.SetEndian(LittleEndian)
+	b.PutU32(1)
+
+	Release(b)
+	assert.Equal(t, 0, b.Count())
+	assert.Equal(t, 0, b.Capacity())
+	Release(b) // no-op
+	Release(nil)
+
+	// Whether or not the array is reused, settings start fresh and the
+	// capacity is sufficient
+	for _, n := range []int{16, 64, 1024} {
+		c := Acquire(n)
+		assert.GreaterOrEqual(t, c.Capacity(), n)
+		assert.Equal(t, 0, c.Count())
+		assert.Equal(t, 0, c.Pos())
+		assert.Equal(t, BigEndian, c.GetEndian())
+		c.PutU8(1)
+		Release(c)
+	}
+	assert.Panics(t, func() { Acquire(-1) })
+}
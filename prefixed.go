@@ -0,0 +1,99 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// takeArrCount reads an element count prefix of the given width (PrefixUvarint,
+// 1, 2 or 4) at the current position and checks that count elements of
+// elemSize bytes follow it. It returns the count and the prefix length without
+// advancing the position, or ok false after recording the failure.
+func (b *Buffer) takeArrCount(method string, prefixWidth, elemSize int) (count, prefixLen int, ok bool) {
+	checkPrefixWidth("Buffer."+method, prefixWidth)
+	if prefixWidth == PrefixUvarint {
+		if b.err != nil {
+			return 0, 0, false
+		}
+		v, k := binary.Uvarint(b.data[b.pos:])
+		if k <= 0 || v > uint64(len(b.data)) {
+			b.fail(fmt.Errorf("mbuff.Buffer.%s: malformed uvarint prefix at pos %d: %w", method, b.pos, ErrOutOfRange))
+			return 0, 0, false
+		}
+		count, prefixLen = int(v), k
+	} else {
+		if !b.checkReadable(prefixWidth) {
+			return 0, 0, false
+		}
+		count, prefixLen = int(b.uintN(b.pos, prefixWidth)), prefixWidth
+	}
+	if count > (len(b.data)-b.pos-prefixLen)/elemSize {
+		b.fail(fmt.Errorf("mbuff.Buffer.%s: count %d at pos %d exceeds readable data: %w", method, count, b.pos, ErrOutOfRange))
+		return 0, 0, false
+	}
+	return count, prefixLen, true
+}
+
+// TakeLenPrefixedArr8 reads an element count prefix of the given width
+// (PrefixUvarint, 1, 2 or 4) followed by that many bytes, and returns them in
+// a new slice. It then advances the position. The count is validated against
+// the readable data before allocating, and the position is left unchanged if
+// the prefix is malformed or the elements are incomplete.
+// Panics if prefixWidth is invalid.
+func (b *Buffer) TakeLenPrefixedArr8(prefixWidth int) []byte {
+	n, k, ok := b.takeArrCount("TakeLenPrefixedArr8", prefixWidth, 1)
+	if !ok {
+		return nil
+	}
+	b.pos += k
+	v := make([]byte, n)
+	b.TakeArr8(v)
+	return v
+}
+
+// TakeLenPrefixedArr16 reads an element count prefix of the given width
+// (PrefixUvarint, 1, 2 or 4) followed by that many uint16 values, and returns
+// them in a new slice. It then advances the position. See TakeLenPrefixedArr8.
+func (b *Buffer) TakeLenPrefixedArr16(prefixWidth int) []uint16 {
+	n, k, ok := b.takeArrCount("TakeLenPrefixedArr16", prefixWidth, 2)
+	if !ok {
+		return nil
+	}
+	b.pos += k
+	v := make([]uint16, n)
+	b.TakeArr16(v)
+	return v
+}
+
+// TakeLenPrefixedArr32 reads an element count prefix of the given width
+// (PrefixUvarint, 1, 2 or 4) followed by that many uint32 values, and returns
+// them in a new slice. It then advances the position. See TakeLenPrefixedArr8.
+func (b *Buffer) TakeLenPrefixedArr32(prefixWidth int) []uint32 {
+	n, k, ok := b.takeArrCount("TakeLenPrefixedArr32", prefixWidth, 4)
+	if !ok {
+		return nil
+	}
+	b.pos += k
+	v := make([]uint32, n)
+	b.TakeArr32(v)
+	return v
+}
+
+// TakeLenPrefixedArr64 reads an element count prefix of the given width
+// (PrefixUvarint, 1, 2 or 4) followed by that many uint64 values, and returns
+// them in a new slice. It then advances the position. See TakeLenPrefixedArr8.
+func (b *Buffer) TakeLenPrefixedArr64(prefixWidth int) []uint64 {
+	n, k, ok := b.takeArrCount("TakeLenPrefixedArr64", prefixWidth, 8)
+	if !ok {
+		return nil
+	}
+	b.pos += k
+	v := make([]uint64, n)
+	b.TakeArr64(v)
+	return v
+}
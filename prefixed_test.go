@@ -0,0 +1,56 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTakeLenPrefixedArr tests reading count-prefixed arrays.
+func TestTakeLenPrefixedArr(t *testing.T) {
+	b := NewBuilder(0)
+	b.SetEndian(LittleEndian)
+	b.PutU8(3)
+	b.PutArr8([]byte{1, 2, 3})
+	b.PutU16(2)
+	b.PutArr16([]uint16{0x0102, 0x0304})
+	b.PutU32(1)
+	b.PutArr32([]uint32{0x05060708})
+	b.PutU8(2) // uvarint
+	b.PutArr64([]uint64{9, 10})
+	b.PutU16(0)
+	b.Rewind()
+
+	assert.Equal(t, []byte{1, 2, 3}, b.TakeLenPrefixedArr8(1))
+	assert.Equal(t, []uint16{0x0102, 0x0304}, b.TakeLenPrefixedArr16(2))
+	assert.Equal(t, []uint32{0x05060708}, b.TakeLenPrefixedArr32(4))
+	assert.Equal(t, []uint64{9, 10}, b.TakeLenPrefixedArr64(PrefixUvarint))
+	assert.Equal(t, []uint16{}, b.TakeLenPrefixedArr16(2))
+	assert.Equal(t, 0, b.Readable())
+
+	// Counts beyond the readable data are rejected before allocating
+	cases := []struct {
+		width int
+		in    []byte
+	}{
+		{4, []byte{0xFF, 0xFF, 0xFF, 0xFF, 0, 0}},
+		{1, []byte{0x02, 0, 0, 0}},
+		{PrefixUvarint, []byte{0x80}},
+		{PrefixUvarint, []byte{0x02, 0, 0, 0}},
+		{2, []byte{0x01}},
+	}
+	for _, c := range cases {
+		r := NewBufferFrom(c.in)
+		r.SetStrictMode(true)
+		assert.Nil(t, r.TakeLenPrefixedArr16(c.width), "in=%X", c.in)
+		assert.ErrorIs(t, r.Err(), ErrOutOfRange)
+		assert.Equal(t, 0, r.Pos())
+	}
+	assert.Panics(t, func() { NewBufferFrom([]byte{0x02, 0, 0, 0, 0}).TakeLenPrefixedArr32(1) })
+	assert.Panics(t, func() { b.TakeLenPrefixedArr8(3) })
+}
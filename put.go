@@ -5,91 +5,118 @@
 
 package mbuff
 
-// PutU8 writes a uint8 at the current position and advances the position.
-// Panics if the write would exceed the buffer's capacity.
-func (b *Buffer) PutU8(v uint8) {
-	required := b.pos + 1
+import (
+	"fmt"
+)
+
+// checkWritable checks if required is within the capacity, then extends the
+// count to required if needed.
+func (b *Buffer) checkWritable(method string, required int) bool {
+	if b.err != nil {
+		return false
+	}
 	if required > len(b.data) {
 		if required > cap(b.data) {
-			panic("mbuff.Buffer.PutU8: buffer overflow")
+			return b.fail(fmt.Errorf("mbuff.Buffer.%s: buffer overflow: %w", method, ErrOutOfRange))
 		}
 		b.data = b.data[:required]
 	}
+	return true
+}
+
+// PutU8 writes a uint8 at the current position and advances the position.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutU8(v uint8) {
+	required := b.pos + 1
+	if !b.checkWritable("PutU8", required) {
+		return
+	}
 
+	b.traceOp("PutU8", 1, uint64(v))
 	b.data[b.pos] = v
 	b.pos += 1
 }
 
 // PutU16 writes a uint16 at the current position and advances the position.
-// Panics if the write would exceed the buffer's capacity.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
 func (b *Buffer) PutU16(v uint16) {
 	required := b.pos + 2
-	if required > len(b.data) {
-		if required > cap(b.data) {
-			panic("mbuff.Buffer.PutU16: buffer overflow")
-		}
-		b.data = b.data[:required]
+	if !b.checkWritable("PutU16", required) {
+		return
 	}
 
+	b.traceOp("PutU16", 2, uint64(v))
 	b.order.PutUint16(b.data[b.pos:], v)
 	b.pos += 2
 }
 
 // PutU32 writes a uint32 at the current position and advances the position.
-// Panics if the write would exceed the buffer's capacity.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
 func (b *Buffer) PutU32(v uint32) {
 	required := b.pos + 4
-	if required > len(b.data) {
-		if required > cap(b.data) {
-			panic("mbuff.Buffer.PutU32: buffer overflow")
-		}
-		b.data = b.data[:required]
+	if !b.checkWritable("PutU32", required) {
+		return
 	}
 
+	b.traceOp("PutU32", 4, uint64(v))
 	b.order.PutUint32(b.data[b.pos:], b.HLSwap32(v))
 	b.pos += 4
 }
 
 // PutU64 writes a uint64 at the current position and advances the position.
-// Panics if the write would exceed the buffer's capacity.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
 func (b *Buffer) PutU64(v uint64) {
 	required := b.pos + 8
-	if required > len(b.data) {
-		if required > cap(b.data) {
-			panic("mbuff.Buffer.PutU64: buffer overflow")
-		}
-		b.data = b.data[:required]
+	if !b.checkWritable("PutU64", required) {
+		return
 	}
 
+	b.traceOp("PutU64", 8, v)
 	b.order.PutUint64(b.data[b.pos:], b.HLSwap64(v))
 	b.pos += 8
 }
 
 // PutArr8 writes a byte slice at the current position and advances the position.
-// Panics if the write would exceed the buffer's capacity.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
 func (b *Buffer) PutArr8(v []byte) {
 	required := b.pos + len(v)
-	if required > len(b.data) {
-		if required > cap(b.data) {
-			panic("mbuff.Buffer.PutArr8: buffer overflow")
-		}
-		b.data = b.data[:required]
+	if !b.checkWritable("PutArr8", required) {
+		return
 	}
 
+	b.traceOp("PutArr8", len(v), 0)
 	n := copy(b.data[b.pos:], v)
 	b.pos += n
 }
 
+// PutStr writes the raw bytes of s at the current position and advances the position.
+// No length prefix is written.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutStr(s string) {
+	required := b.pos + len(s)
+	if !b.checkWritable("PutStr", required) {
+		return
+	}
+
+	b.traceOp("PutStr", len(s), 0)
+	n := copy(b.data[b.pos:], s)
+	b.pos += n
+}
+
 // PutArr16 writes a uint16 slice at the current position and advances the position.
-// Panics if the write would exceed the buffer's capacity.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
 func (b *Buffer) PutArr16(v []uint16) {
 	byteLen := len(v) << 1
 	required := b.pos + byteLen
-	if required > len(b.data) {
-		if required > cap(b.data) {
-			panic("mbuff.Buffer.PutArr16: buffer overflow")
-		}
-		b.data = b.data[:required]
+	if !b.checkWritable("PutArr16", required) {
+		return
+	}
+
+	b.traceOp("PutArr16", byteLen, 0)
+	if b.isHostOrder() {
+		copy(b.data[b.pos:], bytesOf16(v))
+		b.pos += byteLen
+		return
 	}
 
 	writePos := b.pos
@@ -101,15 +128,19 @@ func (b *Buffer) PutArr16(v []uint16) {
 }
 
 // PutArr32 writes a uint32 slice at the current position and advances the position.
-// Panics if the write would exceed the buffer's capacity.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
 func (b *Buffer) PutArr32(v []uint32) {
 	byteLen := len(v) << 2
 	required := b.pos + byteLen
-	if required > len(b.data) {
-		if required > cap(b.data) {
-			panic("mbuff.Buffer.PutArr32: buffer overflow")
-		}
-		b.data = b.data[:required]
+	if !b.checkWritable("PutArr32", required) {
+		return
+	}
+
+	b.traceOp("PutArr32", byteLen, 0)
+	if b.isHostLayout() {
+		copy(b.data[b.pos:], bytesOf32(v))
+		b.pos += byteLen
+		return
 	}
 
 	writePos := b.pos
@@ -121,15 +152,19 @@ func (b *Buffer) PutArr32(v []uint32) {
 }
 
 // PutArr64 writes a uint64 slice at the current position and advances the position.
-// Panics if the write would exceed the buffer's capacity.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
 func (b *Buffer) PutArr64(v []uint64) {
 	byteLen := len(v) << 3
 	required := b.pos + byteLen
-	if required > len(b.data) {
-		if required > cap(b.data) {
-			panic("mbuff.Buffer.PutArr64: buffer overflow")
-		}
-		b.data = b.data[:required]
+	if !b.checkWritable("PutArr64", required) {
+		return
+	}
+
+	b.traceOp("PutArr64", byteLen, 0)
+	if b.isHostLayout() {
+		copy(b.data[b.pos:], bytesOf64(v))
+		b.pos += byteLen
+		return
 	}
 
 	writePos := b.pos
@@ -139,3 +174,116 @@ func (b *Buffer) PutArr64(v []uint64) {
 	}
 	b.pos += byteLen
 }
+
+// PutArr32Func writes each element of v transformed by fn at the current
+// position and advances the position. v itself is not modified.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutArr32Func(v []uint32, fn func(uint32) uint32) {
+	byteLen := len(v) << 2
+	required := b.pos + byteLen
+	if !b.checkWritable("PutArr32Func", required) {
+		return
+	}
+
+	writePos := b.pos
+	for _, val := range v {
+		b.order.PutUint32(b.data[writePos:], b.HLSwap32(fn(val)))
+		writePos += 4
+	}
+	b.pos += byteLen
+}
+
+// interleavedLen validates the sources for InterleaveArr32 and returns the
+// number of records they hold.
+func interleavedLen(stride int, sources [][]uint32) (int, error) {
+	if stride <= 0 {
+		return 0, fmt.Errorf("mbuff.Buffer.InterleaveArr32: invalid stride %d", stride)
+	}
+	if len(sources) == 0 {
+		return 0, nil
+	}
+	n := len(sources[0])
+	for i, src := range sources {
+		if len(src) != n {
+			return 0, fmt.Errorf("mbuff.Buffer.InterleaveArr32: source %d has %d elements, want %d", i, len(src), n)
+		}
+	}
+	if n%stride != 0 {
+		return 0, fmt.Errorf("mbuff.Buffer.InterleaveArr32: source length %d is not a multiple of stride %d", n, stride)
+	}
+	return n / stride, nil
+}
+
+// InterleaveArr32 writes the sources record by record at the current position
+// and advances the position. Each record takes stride consecutive elements from
+// sources[0], then stride from sources[1], and so on; for example stride 1 with
+// sources a and b writes a[0], b[0], a[1], b[1], ...
+// All sources must have the same length, which must be a multiple of stride;
+// otherwise an error is returned and nothing is written.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) InterleaveArr32(stride int, sources ...[]uint32) error {
+	records, err := interleavedLen(stride, sources)
+	if err != nil {
+		return err
+	}
+	byteLen := (records * stride * len(sources)) << 2
+	required := b.pos + byteLen
+	if !b.checkWritable("InterleaveArr32", required) {
+		return nil
+	}
+
+	writePos := b.pos
+	for r := 0; r < records; r++ {
+		start := r * stride
+		for _, src := range sources {
+			for _, val := range src[start : start+stride] {
+				b.order.PutUint32(b.data[writePos:], b.HLSwap32(val))
+				writePos += 4
+			}
+		}
+	}
+	b.pos += byteLen
+	return nil
+}
+
+// PutU16In writes a uint16 in byte order e at the current position and advances
+// the position, without changing the buffer's byte order.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutU16In(v uint16, e Endian) {
+	required := b.pos + 2
+	if !b.checkWritable("PutU16In", required) {
+		return
+	}
+
+	b.traceOp("PutU16In", 2, uint64(v))
+	e.byteOrder().PutUint16(b.data[b.pos:], v)
+	b.pos += 2
+}
+
+// PutU32In writes a uint32 in byte order e at the current position and advances
+// the position, without changing the buffer's byte order. High-low swap still applies.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutU32In(v uint32, e Endian) {
+	required := b.pos + 4
+	if !b.checkWritable("PutU32In", required) {
+		return
+	}
+
+	b.traceOp("PutU32In", 4, uint64(v))
+	e.byteOrder().PutUint32(b.data[b.pos:], b.HLSwap32(v))
+	b.pos += 4
+}
+
+// PutU64In writes a uint64 in byte order e at the current position and advances
+// the position, without changing the buffer's byte order. High-low swap still applies.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutU64In(v uint64, e Endian) {
+	required := b.pos + 8
+	if !b.checkWritable("PutU64In", required) {
+		return
+	}
+
+	b.traceOp("PutU64In", 8, v)
+	e.byteOrder().PutUint64(b.data[b.pos:], b.HLSwap64(v))
+	b.pos += 8
+}
@@ -203,3 +203,399 @@ func TestPutTakeArr(t *testing.T) {
 		b.PutArr64([]uint64{0x00})
 	}, "PutArr64 should panic on buffer overflow")
 }
+
+// TestInterleaveArr32 tests writing interleaved records from several sources.
+func TestInterleaveArr32(t *testing.T) {
+	b := NewBuffer(64)
+	pos := []uint32{1, 2, 3, 4, 5, 6}
+	col := []uint32{7, 8, 9, 10, 11, 12}
+	assert.NoError(t, b.InterleaveArr32(3, pos, col))
+	assert.Equal(t, 48, b.Pos())
+
+	out := make([]uint32, 12)
+	b.Rewind()
+	b.TakeArr32(out)
+	assert.Equal(t, []uint32{1, 2, 3, 7, 8, 9, 4, 5, 6, 10, 11, 12}, out)
+
+	b.Clear()
+	assert.NoError(t, b.InterleaveArr32(1, []uint32{1, 2}, []uint32{3, 4}))
+	b.Rewind()
+	out = make([]uint32, 4)
+	b.TakeArr32(out)
+	assert.Equal(t, []uint32{1, 3, 2, 4}, out)
+
+	// Invalid input writes nothing
+	b.Clear()
+	assert.Error(t, b.InterleaveArr32(0, pos))
+	assert.Error(t, b.InterleaveArr32(1, pos, col[:5]))
+	assert.Error(t, b.InterleaveArr32(4, pos, col))
+	assert.Equal(t, 0, b.Count())
+	assert.NoError(t, b.InterleaveArr32(1))
+
+	// Overflow panics
+	b = NewBuffer(8)
+	assert.Panics(t, func() { _ = b.InterleaveArr32(1, pos) })
+}
+
+// TestPutTakeArr32Func tests writing and reading arrays with a per-element transform.
+func TestPutTakeArr32Func(t *testing.T) {
+	xor := func(v uint32) uint32 { return v ^ 0xA5A5A5A5 }
+	in := []uint32{0x00000000, 0x11223344, 0xFFFFFFFF}
+
+	b := NewBuffer(16)
+	b.PutArr32Func(in, xor)
+	assert.Equal(t, 12, b.Pos())
+	assert.Equal(t, []uint32{0x00000000, 0x11223344, 0xFFFFFFFF}, in, "source must not be modified")
+
+	raw := make([]uint32, 3)
+	b.Rewind()
+	b.TakeArr32(raw)
+	assert.Equal(t, []uint32{0xA5A5A5A5, 0xB48796E1, 0x5A5A5A5A}, raw)
+
+	out := make([]uint32, 3)
+	b.Rewind()
+	b.TakeArr32Func(out, xor)
+	assert.Equal(t, in, out)
+
+	// High-low swap applies to the transformed value
+	b = NewBuffer(4)
+	b.SetHLSwap(true)
+	b.PutArr32Func([]uint32{0x11223344}, func(v uint32) uint32 { return v + 1 })
+	b.Rewind()
+	b.SetHLSwap(false)
+	assert.Equal(t, uint32(0x22114533), b.TakeU32())
+
+	// Builder grows as needed
+	bb := NewBuilder(0)
+	bb.PutArr32Func(in, xor)
+	assert.Equal(t, 12, bb.Count())
+
+	// Overflow panics
+	b = NewBuffer(8)
+	assert.Panics(t, func() { b.PutArr32Func(in, xor) })
+	assert.Panics(t, func() { b.TakeArr32Func(out, xor) })
+}
+
+// TestPutTakeArr_HostOrder tests array round trips in both byte orders, so
+// that both the bulk copy and the portable path are covered on any host.
+func TestPutTakeArr_HostOrder(t *testing.T) {
+	in16 := []uint16{0x1122, 0x3344}
+	in32 := []uint32{0x11223344, 0x55667788}
+	in64 := []uint64{0x1122334455667788}
+
+	for _, e := range []Endian{BigEndian, LittleEndian} {
+		for _, swap := range []bool{false, true} {
+			b := NewBuffer(64)
+			b.SetEndian(e)
+			b.SetHLSwap(swap)
+			b.PutArr16(in16)
+			b.PutArr32(in32)
+			b.PutArr64(in64)
+
+			// Compare against the scalar encoding
+			ref := NewBuffer(64)
+			ref.SetEndian(e)
+			ref.SetHLSwap(swap)
+			ref.PutU16(in16[0])
+			ref.PutU16(in16[1])
+			ref.PutU32(in32[0])
+			ref.PutU32(in32[1])
+			ref.PutU64(in64[0])
+			assert.Equal(t, ref.Bytes(), b.Bytes())
+
+			out16 := make([]uint16, 2)
+			out32 := make([]uint32, 2)
+			out64 := make([]uint64, 1)
+			b.Rewind()
+			b.TakeArr16(out16)
+			b.TakeArr32(out32)
+			b.TakeArr64(out64)
+			assert.Equal(t, in16, out16)
+			assert.Equal(t, in32, out32)
+			assert.Equal(t, in64, out64)
+		}
+	}
+}
+
+func benchmarkPutTakeArr64(b *testing.B, e Endian) {
+	v := make([]uint64, 4096)
+	for i := range v {
+		v[i] = uint64(i) * 0x0101010101010101
+	}
+	buf := NewBuffer(len(v) << 3)
+	buf.SetEndian(e)
+	b.SetBytes(int64(len(v) << 3))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Clear()
+		buf.PutArr64(v)
+		buf.Rewind()
+		buf.TakeArr64(v)
+	}
+}
+
+// BenchmarkPutTakeArr64_Host measures the bulk copy path (on little-endian hosts).
+func BenchmarkPutTakeArr64_Host(b *testing.B) { benchmarkPutTakeArr64(b, LittleEndian) }
+
+// BenchmarkPutTakeArr64_Portable measures the element-by-element path (on little-endian hosts).
+func BenchmarkPutTakeArr64_Portable(b *testing.B) { benchmarkPutTakeArr64(b, BigEndian) }
+
+// TestPutTakeStr tests writing and reading raw string bytes.
+func TestPutTakeStr(t *testing.T) {
+	b := NewBuffer(8)
+	b.PutStr("abc")
+	b.PutStr("")
+	b.PutStr("de")
+	assert.Equal(t, []byte("abcde"), b.Bytes())
+	assert.Equal(t, 5, b.Pos())
+	assert.Panics(t, func() { b.PutStr("wxyz") })
+
+	b.Rewind()
+	assert.Equal(t, "abc", b.TakeStr(3))
+	assert.Equal(t, "", b.TakeStr(0))
+	assert.Equal(t, 3, b.Pos())
+	assert.Panics(t, func() { b.TakeStr(3) })
+	assert.Panics(t, func() { b.TakeStr(-1) })
+	assert.Equal(t, "de", b.TakeStr(2))
+
+	// Builder grows
+	bb := NewBuilder(0)
+	bb.PutStr("hello, ")
+	bb.PutStr("world")
+	assert.Equal(t, "hello, world", string(bb.Bytes()))
+}
+
+// TestTakeBytesInto tests reading into a reusable scratch slice.
+func TestTakeBytesInto(t *testing.T) {
+	b := NewBufferFrom([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9})
+
+	scratch := make([]byte, 0, 4)
+	out := b.TakeBytesInto(3, scratch)
+	assert.Equal(t, []byte{1, 2, 3}, out)
+	assert.Equal(t, &scratch[:1][0], &out[0]) // reused
+
+	out = b.TakeBytesInto(6, out)
+	assert.Equal(t, []byte{4, 5, 6, 7, 8, 9}, out)
+	assert.Equal(t, 6, cap(out)) // reallocated
+	assert.Equal(t, 9, b.Pos())
+
+	out = b.TakeBytesInto(0, out)
+	assert.Len(t, out, 0)
+
+	b.Seek(8)
+	assert.Panics(t, func() { b.TakeBytesInto(2, out) })
+	assert.Panics(t, func() { b.TakeBytesInto(-1, out) })
+	assert.Equal(t, 8, b.Pos())
+
+	// Error mode returns an empty slice
+	b.SetStrictMode(true)
+	assert.Len(t, b.TakeBytesInto(2, scratch), 0)
+	assert.Error(t, b.Err())
+}
+
+// TestExpect tests validating constant fields.
+func TestExpect(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutU8(0x01)
+	b.PutU16(0x0203)
+	b.PutU32(0x04050607)
+	b.PutU64(0x08090A0B0C0D0E0F)
+	b.Rewind()
+
+	assert.NoError(t, b.ExpectU8(0x01))
+	assert.NoError(t, b.ExpectU16(0x0203))
+	err := b.ExpectU32(0x04050608)
+	assert.EqualError(t, err, "mbuff.Buffer.ExpectU32: field at offset 3: expected 0x04050608, got 0x04050607")
+	assert.Equal(t, 3, b.Pos())
+	assert.NoError(t, b.ExpectU32(0x04050607))
+	assert.ErrorContains(t, b.ExpectU64(1), "expected 0x0000000000000001, got 0x08090A0B0C0D0E0F")
+	assert.NoError(t, b.ExpectU64(0x08090A0B0C0D0E0F))
+	assert.Equal(t, 15, b.Pos())
+
+	b.Rewind()
+	assert.EqualError(t, b.ExpectU8(0x02), "mbuff.Buffer.ExpectU8: field at offset 0: expected 0x02, got 0x01")
+
+	// Truncated input
+	assert.Panics(t, func() { _ = NewBufferFrom([]byte{1}).ExpectU16(1) })
+	r := NewBufferFrom([]byte{1})
+	r.SetStrictMode(true)
+	assert.ErrorIs(t, r.ExpectU16(1), ErrOutOfRange)
+}
+
+// TestExpectEnd tests checking that all data has been read.
+func TestExpectEnd(t *testing.T) {
+	b := NewBufferFrom([]byte{1, 2, 3})
+	assert.False(t, b.AtEnd())
+	assert.EqualError(t, b.ExpectEnd(), "mbuff.Buffer.ExpectEnd: 3 trailing bytes at pos 0")
+	b.TakeU16()
+	assert.EqualError(t, b.ExpectEnd(), "mbuff.Buffer.ExpectEnd: 1 trailing bytes at pos 2")
+	b.TakeU8()
+	assert.True(t, b.AtEnd())
+	assert.NoError(t, b.ExpectEnd())
+	assert.True(t, NewBuffer(0).AtEnd())
+
+	b.SetStrictMode(true)
+	b.TakeU8()
+	assert.ErrorIs(t, b.ExpectEnd(), ErrOutOfRange)
+}
+
+// TestTakeU16Until tests reading sentinel-terminated uint16 runs.
+func TestTakeU16Until(t *testing.T) {
+	b := NewBuilder(0)
+	b.SetEndian(LittleEndian)
+	b.PutArr16([]uint16{1, 2, 3, 0xFFFF, 0xFFFF, 4, 0xFFFF})
+	b.Rewind()
+
+	assert.Equal(t, []uint16{1, 2, 3}, b.TakeU16Until(0xFFFF))
+	assert.Equal(t, 8, b.Pos())
+	assert.Equal(t, []uint16{}, b.TakeU16Until(0xFFFF))
+	assert.Equal(t, []uint16{4}, b.TakeU16Until(0xFFFF))
+	assert.Equal(t, 0, b.Readable())
+
+	// Missing sentinel, including an odd trailing byte
+	r := NewBufferFrom([]byte{0x00, 0x01, 0xFF})
+	r.SetStrictMode(true)
+	assert.Nil(t, r.TakeU16Until(0xFFFF))
+	assert.ErrorIs(t, r.Err(), ErrOutOfRange)
+	assert.Equal(t, 0, r.Pos())
+	assert.Panics(t, func() { NewBuffer(0).TakeU16Until(0) })
+}
+
+// TestTakeU32UntilZero tests reading zero-terminated uint32 arrays.
+func TestTakeU32UntilZero(t *testing.T) {
+	b := NewBuilder(0)
+	b.SetEndian(LittleEndian)
+	b.SetHLSwap(true)
+	b.PutArr32([]uint32{1, 0x00010000, 0, 0, 7, 0})
+	b.Rewind()
+
+	assert.Equal(t, []uint32{1, 0x00010000}, b.TakeU32UntilZero())
+	assert.Equal(t, 12, b.Pos())
+	assert.Equal(t, []uint32{}, b.TakeU32UntilZero())
+	assert.Equal(t, []uint32{7}, b.TakeU32UntilZero())
+	assert.True(t, b.AtEnd())
+
+	// Zero bytes straddling elements are not a terminator
+	r := NewBufferFrom([]byte{1, 0, 0, 0, 0, 2, 0, 0})
+	r.SetStrictMode(true)
+	assert.Nil(t, r.TakeU32UntilZero())
+	assert.ErrorIs(t, r.Err(), ErrOutOfRange)
+	assert.Equal(t, 0, r.Pos())
+	assert.Panics(t, func() { NewBufferFrom([]byte{0, 0, 0}).TakeU32UntilZero() })
+}
+
+// TestTakeRune tests reading UTF-8 runes with their encoded length.
+func TestTakeRune(t *testing.T) {
+	b := NewBufferFrom([]byte("aé😀�"))
+	for _, want := range []struct {
+		r rune
+		n int
+	}{{'a', 1}, {'é', 2}, {'😀', 4}, {'�', 3}} {
+		r, n := b.PeekRune(0)
+		assert.Equal(t, want.r, r)
+		assert.Equal(t, want.n, n)
+		r, n = b.TakeRune()
+		assert.Equal(t, want.r, r)
+		assert.Equal(t, want.n, n)
+	}
+	assert.True(t, b.AtEnd())
+
+	r, n := NewBufferFrom([]byte("xé")).PeekRune(1)
+	assert.Equal(t, 'é', r)
+	assert.Equal(t, 2, n)
+
+	// Truncated and invalid encodings leave the position unchanged
+	for _, in := range [][]byte{{}, {0xF0, 0x9F}, {0xFF}, {0xC0, 0x80}} {
+		c := NewBufferFrom(in)
+		c.SetStrictMode(true)
+		r, n := c.TakeRune()
+		assert.Equal(t, 0, int(r)+n)
+		assert.Error(t, c.Err(), "in=%X", in)
+		assert.Equal(t, 0, c.Pos())
+	}
+	assert.Panics(t, func() { NewBufferFrom([]byte{0xE2, 0x82}).TakeRune() })
+}
+
+// TestTakeCStr tests reading NUL-terminated strings with their encoded length.
+func TestTakeCStr(t *testing.T) {
+	b := NewBufferFrom([]byte("ab\x00\x00cde\x00"))
+	s, n := b.PeekCStr(4)
+	assert.Equal(t, "cde", s)
+	assert.Equal(t, 4, n)
+
+	s, n = b.TakeCStr()
+	assert.Equal(t, "ab", s)
+	assert.Equal(t, 3, n)
+	s, n = b.TakeCStr()
+	assert.Equal(t, "", s)
+	assert.Equal(t, 1, n)
+	s, n = b.TakeCStr()
+	assert.Equal(t, "cde", s)
+	assert.Equal(t, 4, n)
+	assert.True(t, b.AtEnd())
+
+	// A missing terminator leaves the position unchanged
+	r := NewBufferFrom([]byte("abc"))
+	assert.Panics(t, func() { r.TakeCStr() })
+	r.SetStrictMode(true)
+	s, n = r.TakeCStr()
+	assert.Equal(t, "", s)
+	assert.Equal(t, 0, n)
+	assert.ErrorIs(t, r.Err(), ErrOutOfRange)
+	assert.Equal(t, 0, r.Pos())
+	assert.Panics(t, func() { NewBufferFrom([]byte("a\x00")).PeekCStr(3) })
+}
+
+// TestTryTake tests reading that returns errors instead of panicking.
+func TestTryTake(t *testing.T) {
+	b := NewBuffer(16)
+	b.PutU8(0x01)
+	b.PutU16(0x0203)
+	b.PutU32(0x04050607)
+	b.PutU64(0x08090A0B0C0D0E0F)
+	b.Rewind()
+
+	v8, err := b.TryTakeU8()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(0x01), v8)
+	v16, err := b.TryTakeU16()
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(0x0203), v16)
+	v32, err := b.TryTakeU32()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0x04050607), v32)
+	v64, err := b.TryTakeU64()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0x08090A0B0C0D0E0F), v64)
+	assert.Equal(t, 15, b.Pos())
+
+	// Failures leave the position unchanged
+	_, err = b.TryTakeU8()
+	assert.EqualError(t, err, "mbuff.Buffer.TryTakeU8: read of 1 bytes at pos 15 exceeds count 15: mbuff: out of range")
+	b.Seek(14)
+	_, err = b.TryTakeU16()
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	_, err = b.TryTakeU32()
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	_, err = b.TryTakeU64()
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	p := []byte{0xEE, 0xEE}
+	assert.ErrorIs(t, b.TryTakeArr8(p), ErrOutOfRange)
+	assert.Equal(t, []byte{0xEE, 0xEE}, p)
+	assert.Equal(t, 14, b.Pos())
+	assert.NoError(t, b.TryTakeArr8(p[:1]))
+	assert.Equal(t, []byte{0x0F, 0xEE}, p)
+	assert.NoError(t, b.TryTakeArr8(nil))
+
+	// Try methods do not touch the sticky error and work despite one
+	b.SetStrictMode(true)
+	_, err = b.TryTakeU8()
+	assert.Error(t, err)
+	assert.NoError(t, b.Err())
+	b.TakeU8()
+	assert.Error(t, b.Err())
+	b.Seek(0)
+	v8, err = b.TryTakeU8()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(0x01), v8)
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"fmt"
+)
+
+// PutAll writes each value in turn with the current byte order and high-low
+// swap, then advances the position. Supported types are uint8, uint16,
+// uint32, uint64, their signed counterparts (as two's complement), bool (as
+// one byte, 0 or 1), []byte and string (raw bytes, no length prefix). int and
+// uint are rejected since their width depends on the platform.
+// All values are type-checked first, so an unsupported type returns an error
+// without writing anything. Returns the recorded error if a write fails in
+// error mode. The buffer will automatically grow if necessary.
+func (b *Builder) PutAll(values ...any) error {
+	n := 0
+	for i, v := range values {
+		switch v := v.(type) {
+		case uint8, int8, bool:
+			n++
+		case uint16, int16:
+			n += 2
+		case uint32, int32:
+			n += 4
+		case uint64, int64:
+			n += 8
+		case []byte:
+			n += len(v)
+		case string:
+			n += len(v)
+		default:
+			return fmt.Errorf("mbuff.Builder.PutAll: value %d has unsupported type %T", i, v)
+		}
+	}
+	if !b.ensure(b.pos + n) {
+		return b.err
+	}
+
+	for _, v := range values {
+		switch v := v.(type) {
+		case uint8:
+			b.PutU8(v)
+		case int8:
+			b.PutI8(v)
+		case bool:
+			b.PutBool(v)
+		case uint16:
+			b.PutU16(v)
+		case int16:
+			b.PutI16(v)
+		case uint32:
+			b.PutU32(v)
+		case int32:
+			b.PutI32(v)
+		case uint64:
+			b.PutU64(v)
+		case int64:
+			b.PutI64(v)
+		case []byte:
+			b.PutArr8(v)
+		case string:
+			b.PutStr(v)
+		}
+	}
+	return b.err
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBuilder_PutAll tests writing mixed values in one call.
+func TestBuilder_PutAll(t *testing.T) {
+	b := NewBuilder(0)
+	payload := []byte{0xDE, 0xAD}
+	assert.NoError(t, b.PutAll(uint8(0x7E), uint16(1), uint32(len(payload)), payload))
+	assert.Equal(t, []byte{0x7E, 0, 1, 0, 0, 0, 2, 0xDE, 0xAD}, b.Bytes())
+
+	b.Clear()
+	b.SetEndian(LittleEndian)
+	assert.NoError(t, b.PutAll(int8(-1), int16(-2), int32(-3), int64(-4), uint64(5), true, false, "ok"))
+	assert.Equal(t, []byte{
+		0xFF,
+		0xFE, 0xFF,
+		0xFD, 0xFF, 0xFF, 0xFF,
+		0xFC, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+		5, 0, 0, 0, 0, 0, 0, 0,
+		1, 0, 'o', 'k',
+	}, b.Bytes())
+
+	// Unsupported types write nothing
+	b.Clear()
+	err := b.PutAll(uint8(1), 2, uint8(3))
+	assert.EqualError(t, err, "mbuff.Builder.PutAll: value 1 has unsupported type int")
+	assert.Error(t, b.PutAll(nil))
+	assert.Equal(t, 0, b.Count())
+	assert.NoError(t, b.PutAll())
+
+	b = NewBuilder(0)
+	b.SetMaxCapacity(4)
+	b.SetStrictMode(true)
+	assert.Error(t, b.PutAll(uint64(1)))
+	assert.Equal(t, 0, b.Count())
+}
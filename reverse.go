@@ -0,0 +1,87 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+)
+
+// checkRange checks that [start, end) lies within the valid data.
+// On success the running checksum is synced, as the caller then modifies data.
+func (b *Buffer) checkRange(method string, start, end int) bool {
+	if b.err != nil {
+		return false
+	}
+	if start < 0 || start > end || end > len(b.data) {
+		return b.fail(fmt.Errorf("mbuff.Buffer.%s: range [%d, %d) out of bounds [0, %d]: %w", method, start, end, len(b.data), ErrOutOfRange))
+	}
+	b.syncChecksum()
+	return true
+}
+
+// checkSwapRange checks that [start, end) lies within the valid data and
+// holds whole elements of the given size.
+func (b *Buffer) checkSwapRange(method string, start, end, size int) bool {
+	if !b.checkRange(method, start, end) {
+		return false
+	}
+	if (end-start)%size != 0 {
+		return b.fail(fmt.Errorf("mbuff.Buffer.%s: range length %d is not a multiple of %d", method, end-start, size))
+	}
+	return true
+}
+
+// ReverseBytes reverses the valid data in [start, end) in place.
+// The position is not changed.
+// Panics if the range is out of bounds, unless error mode is enabled.
+func (b *Buffer) ReverseBytes(start, end int) {
+	if !b.checkRange("ReverseBytes", start, end) {
+		return
+	}
+	slices.Reverse(b.data[start:end])
+}
+
+// SwapU16Range reverses the byte order of each uint16 in [start, end) in
+// place, converting a big-endian array to little-endian or back. The
+// position is not changed.
+// Panics if the range is out of bounds or its length is not a multiple of 2,
+// unless error mode is enabled.
+func (b *Buffer) SwapU16Range(start, end int) {
+	if !b.checkSwapRange("SwapU16Range", start, end, 2) {
+		return
+	}
+	for i := start; i < end; i += 2 {
+		binary.BigEndian.PutUint16(b.data[i:], binary.LittleEndian.Uint16(b.data[i:]))
+	}
+}
+
+// SwapU32Range reverses the byte order of each uint32 in [start, end) in
+// place. The position is not changed.
+// Panics if the range is out of bounds or its length is not a multiple of 4,
+// unless error mode is enabled.
+func (b *Buffer) SwapU32Range(start, end int) {
+	if !b.checkSwapRange("SwapU32Range", start, end, 4) {
+		return
+	}
+	for i := start; i < end; i += 4 {
+		binary.BigEndian.PutUint32(b.data[i:], binary.LittleEndian.Uint32(b.data[i:]))
+	}
+}
+
+// SwapU64Range reverses the byte order of each uint64 in [start, end) in
+// place. The position is not changed.
+// Panics if the range is out of bounds or its length is not a multiple of 8,
+// unless error mode is enabled.
+func (b *Buffer) SwapU64Range(start, end int) {
+	if !b.checkSwapRange("SwapU64Range", start, end, 8) {
+		return
+	}
+	for i := start; i < end; i += 8 {
+		binary.BigEndian.PutUint64(b.data[i:], binary.LittleEndian.Uint64(b.data[i:]))
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestReverseBytes tests reversing a range in place.
+func TestReverseBytes(t *testing.T) {
+	b := NewBufferFrom(seq(1, 6))
+	b.Seek(2)
+	b.ReverseBytes(1, 5)
+	assert.Equal(t, []byte{1, 5, 4, 3, 2, 6}, b.Bytes())
+	b.ReverseBytes(0, 6)
+	assert.Equal(t, []byte{6, 2, 3, 4, 5, 1}, b.Bytes())
+	b.ReverseBytes(3, 3)
+	assert.Equal(t, 2, b.Pos())
+
+	assert.Panics(t, func() { b.ReverseBytes(-1, 2) })
+	assert.Panics(t, func() { b.ReverseBytes(4, 3) })
+	assert.Panics(t, func() { b.ReverseBytes(0, 7) })
+}
+
+// TestSwapRange tests byte-swapping typed arrays in place.
+func TestSwapRange(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutArr16([]uint16{0x0102, 0x0304})
+	b.PutArr32([]uint32{0x05060708})
+	b.PutArr64([]uint64{0x090A0B0C0D0E0F10})
+
+	b.SwapU16Range(0, 4)
+	b.SwapU32Range(4, 8)
+	b.SwapU64Range(8, 16)
+	b.Rewind()
+	b.SetEndian(LittleEndian)
+	assert.Equal(t, uint16(0x0102), b.TakeU16())
+	assert.Equal(t, uint16(0x0304), b.TakeU16())
+	assert.Equal(t, uint32(0x05060708), b.TakeU32())
+	assert.Equal(t, uint64(0x090A0B0C0D0E0F10), b.TakeU64())
+
+	b.SwapU32Range(4, 4)
+	assert.Panics(t, func() { b.SwapU16Range(0, 3) })
+	assert.Panics(t, func() { b.SwapU64Range(8, 24) })
+
+	b.SetStrictMode(true)
+	b.SwapU32Range(0, 6)
+	assert.ErrorContains(t, b.Err(), "range length 6 is not a multiple of 4")
+	b.ClearErr()
+	b.SwapU16Range(-2, 0)
+	assert.ErrorIs(t, b.Err(), ErrOutOfRange)
+}
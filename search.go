@@ -0,0 +1,87 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"bytes"
+)
+
+// Resync searches the readable region for pattern and advances the position
+// to the start of the first match, returning the number of bytes skipped.
+// If the pattern is not found and consumeOnMiss is set, everything but the
+// last len(pattern)-1 bytes is skipped so that a pattern straddling the next
+// refill is not lost; otherwise the position is left unchanged.
+func (b *Buffer) Resync(pattern []byte, consumeOnMiss bool) (skipped int, found bool) {
+	readable := b.data[b.pos:]
+	if i := bytes.Index(readable, pattern); i >= 0 {
+		b.pos += i
+		return i, true
+	}
+	if !consumeOnMiss {
+		return 0, false
+	}
+	skipped = len(readable) - (len(pattern) - 1)
+	if skipped < 0 {
+		skipped = 0
+	}
+	b.pos += skipped
+	return skipped, false
+}
+
+// IndexByte returns the offset from the current position of the first c in
+// the readable region, or -1 if c is not present. The position is not
+// changed.
+func (b *Buffer) IndexByte(c byte) int {
+	return bytes.IndexByte(b.data[b.pos:], c)
+}
+
+// Index returns the offset from the current position of the first instance
+// of pattern in the readable region, or -1 if pattern is not present. An
+// empty pattern matches at offset 0. The position is not changed.
+func (b *Buffer) Index(pattern []byte) int {
+	return bytes.Index(b.data[b.pos:], pattern)
+}
+
+// takeUntil returns the readable bytes before the first delim and advances
+// the position past the delimiter, or returns all readable bytes and moves
+// the position to the end if delim is not present.
+func (b *Buffer) takeUntil(delim byte) ([]byte, bool) {
+	i := b.IndexByte(delim)
+	if i < 0 {
+		v := b.data[b.pos:]
+		b.pos = len(b.data)
+		return v, false
+	}
+	v := b.data[b.pos : b.pos+i]
+	b.pos += i + 1
+	return v, true
+}
+
+// TakeUntil reads the bytes up to, but not including, the next delim into a
+// new slice and advances the position past the delimiter. If delim is not
+// present, all readable bytes are returned, the position moves to the end
+// and found is false.
+func (b *Buffer) TakeUntil(delim byte) (v []byte, found bool) {
+	if b.err != nil {
+		return nil, false
+	}
+	p, found := b.takeUntil(delim)
+	v = make([]byte, len(p))
+	copy(v, p)
+	return v, found
+}
+
+// TakeCString reads a string up to the next NUL and advances the position
+// past the terminator, returning the string without it. Unlike TakeCStr, a
+// missing terminator is not a failure: all readable bytes are returned, the
+// position moves to the end and found is false.
+func (b *Buffer) TakeCString() (s string, found bool) {
+	if b.err != nil {
+		return "", false
+	}
+	p, found := b.takeUntil(0)
+	return string(p), found
+}
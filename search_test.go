@@ -0,0 +1,127 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResync tests scanning forward to a sync pattern.
+func TestResync(t *testing.T) {
+	sync := []byte{0xAA, 0x55}
+	b := NewBufferFrom([]byte{0x01, 0x02, 0xAA, 0x03, 0xAA, 0x55, 0x04})
+
+	skipped, found := b.Resync(sync, false)
+	assert.True(t, found)
+	assert.Equal(t, 4, skipped)
+	assert.Equal(t, 4, b.Pos())
+
+	// Already aligned
+	skipped, found = b.Resync(sync, true)
+	assert.True(t, found)
+	assert.Equal(t, 0, skipped)
+
+	// Miss leaves pos unchanged
+	b.Skip(1)
+	skipped, found = b.Resync(sync, false)
+	assert.False(t, found)
+	assert.Equal(t, 0, skipped)
+	assert.Equal(t, 5, b.Pos())
+
+	// Miss keeps a possible partial match at the end
+	b = NewBufferFrom([]byte{0x01, 0x02, 0x03, 0xAA})
+	skipped, found = b.Resync(sync, true)
+	assert.False(t, found)
+	assert.Equal(t, 3, skipped)
+	assert.Equal(t, []byte{0xAA}, b.ReadableBytes())
+
+	// Fewer readable bytes than the pattern
+	b = NewBufferFrom([]byte{0xAA})
+	skipped, found = b.Resync([]byte{0xAA, 0x55, 0x00}, true)
+	assert.False(t, found)
+	assert.Equal(t, 0, skipped)
+}
+
+// TestIndex tests searching the readable region without consuming it.
+func TestIndex(t *testing.T) {
+	b := NewBufferFrom([]byte("ab\r\ncd\r\n"))
+	assert.Equal(t, 2, b.IndexByte('\r'))
+	assert.Equal(t, 2, b.Index([]byte("\r\n")))
+	assert.Equal(t, 0, b.Pos())
+
+	// Offsets are relative to the position
+	b.Skip(3)
+	assert.Equal(t, 3, b.IndexByte('\r'))
+	assert.Equal(t, 3, b.Index([]byte("\r\n")))
+	assert.Equal(t, 0, b.Index(nil))
+	assert.Equal(t, -1, b.IndexByte('a'))
+	assert.Equal(t, -1, b.Index([]byte("ab")))
+	assert.Equal(t, 3, b.Pos())
+
+	// Data past the count is not searched
+	b = NewBuffer(8)
+	b.PutArr8([]byte{1, 2})
+	b.Rewind()
+	assert.Equal(t, -1, b.IndexByte(0))
+}
+
+// TestTakeUntil tests delimiter-based reads.
+func TestTakeUntil(t *testing.T) {
+	b := NewBufferFrom([]byte("GET /\nHost: x\n\ntail"))
+	v, found := b.TakeUntil('\n')
+	assert.True(t, found)
+	assert.Equal(t, []byte("GET /"), v)
+	assert.Equal(t, 6, b.Pos())
+
+	// The result is a copy
+	v[0] = 'X'
+	assert.Equal(t, byte('G'), b.Bytes()[0])
+
+	v, found = b.TakeUntil('\n')
+	assert.True(t, found)
+	assert.Equal(t, []byte("Host: x"), v)
+	v, found = b.TakeUntil('\n')
+	assert.True(t, found)
+	assert.Equal(t, []byte{}, v)
+
+	// A missing delimiter returns the rest
+	v, found = b.TakeUntil('\n')
+	assert.False(t, found)
+	assert.Equal(t, []byte("tail"), v)
+	assert.Equal(t, b.Count(), b.Pos())
+	v, found = b.TakeUntil('\n')
+	assert.False(t, found)
+	assert.Empty(t, v)
+
+	// A recorded error stops reads
+	b = NewBufferFrom([]byte("a\n"))
+	b.SetStrictMode(true)
+	b.TakeU32()
+	v, found = b.TakeUntil('\n')
+	assert.False(t, found)
+	assert.Nil(t, v)
+	assert.Equal(t, 0, b.Pos())
+}
+
+// TestTakeCString tests reading NUL-terminated strings leniently.
+func TestTakeCString(t *testing.T) {
+	b := NewBufferFrom([]byte("abc\x00\x00de"))
+	s, found := b.TakeCString()
+	assert.True(t, found)
+	assert.Equal(t, "abc", s)
+	assert.Equal(t, 4, b.Pos())
+
+	s, found = b.TakeCString()
+	assert.True(t, found)
+	assert.Equal(t, "", s)
+
+	s, found = b.TakeCString()
+	assert.False(t, found)
+	assert.Equal(t, "de", s)
+	assert.Equal(t, 7, b.Pos())
+}
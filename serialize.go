@@ -0,0 +1,126 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// SerializeVersion is the version of the layout written by Serialize.
+const SerializeVersion = 1
+
+// serializeHeaderLen is the length of the Serialize header.
+const serializeHeaderLen = 10
+
+// Flag bits of the Serialize header.
+const (
+	serializeLittleEndian = 1 << iota
+	serializeHLSwap
+	serializeStrictMode
+
+	serializeKnownFlags = serializeLittleEndian | serializeHLSwap | serializeStrictMode
+)
+
+// Serialize returns the buffer's valid data and state in a self-contained
+// layout that Deserialize reads back. All header fields are big-endian:
+//
+//	offset 0: version (uint8, SerializeVersion)
+//	offset 1: flags (uint8; bit 0 little-endian, bit 1 high-low swap,
+//	          bit 2 strict mode, other bits zero)
+//	offset 2: position (uint32)
+//	offset 6: count (uint32)
+//	offset 10: count bytes of data
+//
+// Capacity, recorded errors, marks, tracing and running checksums are not
+// included. Panics if the count exceeds the uint32 range.
+func (b *Buffer) Serialize() []byte {
+	if uint64(len(b.data)) > 0xFFFFFFFF {
+		panic("mbuff.Buffer.Serialize: count exceeds uint32 range")
+	}
+	var flags uint8
+	if b.GetEndian() == LittleEndian {
+		flags |= serializeLittleEndian
+	}
+	if b.hlswap {
+		flags |= serializeHLSwap
+	}
+	if b.errMode {
+		flags |= serializeStrictMode
+	}
+
+	out := make([]byte, serializeHeaderLen+len(b.data))
+	out[0] = SerializeVersion
+	out[1] = flags
+	binary.BigEndian.PutUint32(out[2:], uint32(b.pos))
+	binary.BigEndian.PutUint32(out[6:], uint32(len(b.data)))
+	copy(out[serializeHeaderLen:], b.data)
+	return out
+}
+
+// Deserialize reconstructs a buffer from the output of Serialize. The buffer
+// holds a copy of the data, with capacity equal to its count.
+// Returns an error if p is truncated or has trailing bytes, the version or
+// flags are unknown, or the position exceeds the count.
+func Deserialize(p []byte) (*Buffer, error) {
+	if len(p) < serializeHeaderLen {
+		return nil, fmt.Errorf("mbuff.Deserialize: header needs %d bytes, got %d: %w", serializeHeaderLen, len(p), ErrOutOfRange)
+	}
+	if p[0] != SerializeVersion {
+		return nil, fmt.Errorf("mbuff.Deserialize: unsupported version %d", p[0])
+	}
+	flags := p[1]
+	if flags&^serializeKnownFlags != 0 {
+		return nil, fmt.Errorf("mbuff.Deserialize: unknown flags 0x%02X", flags)
+	}
+	pos := binary.BigEndian.Uint32(p[2:])
+	count := binary.BigEndian.Uint32(p[6:])
+	if uint64(count) != uint64(len(p)-serializeHeaderLen) {
+		return nil, fmt.Errorf("mbuff.Deserialize: count %d does not match %d data bytes", count, len(p)-serializeHeaderLen)
+	}
+	if pos > count {
+		return nil, fmt.Errorf("mbuff.Deserialize: pos %d exceeds count %d", pos, count)
+	}
+
+	data := make([]byte, count)
+	copy(data, p[serializeHeaderLen:])
+	b := NewBufferFrom(data)
+	b.pos = int(pos)
+	if flags&serializeLittleEndian != 0 {
+		b.SetEndian(LittleEndian)
+	}
+	b.hlswap = flags&serializeHLSwap != 0
+	b.errMode = flags&serializeStrictMode != 0
+	return b, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. It returns a copy of the
+// valid data [0:len]; unlike Serialize, no position or settings are included.
+func (b *Buffer) MarshalBinary() ([]byte, error) {
+	out := make([]byte, len(b.data))
+	copy(out, b.data)
+	return out, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces the
+// valid data with a copy of data, with capacity equal to its length, and
+// resets the position to 0. Any recorded error, marks and recorded trace are
+// discarded, and an enabled running checksum restarts at 0, while the byte
+// order, swap, failure mode and tracing settings are kept.
+func (b *Buffer) UnmarshalBinary(data []byte) error {
+	b.data = make([]byte, len(data))
+	copy(b.data, data)
+	b.pos = 0
+	b.err = nil
+	b.marks = nil
+	b.pushed = nil
+	b.trace = nil
+	b.csPos = 0
+	if b.csHash != nil {
+		b.csHash.Reset()
+	}
+	return nil
+}
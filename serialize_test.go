@@ -0,0 +1,106 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"bytes"
+	"encoding/gob"
+	"hash/crc32"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSerialize tests round-tripping buffer state through Serialize.
+func TestSerialize(t *testing.T) {
+	b := NewBuffer(16)
+	b.SetEndian(LittleEndian)
+	b.SetHLSwap(true)
+	b.PutU16(0x0102)
+	b.PutU8(0x03)
+	b.Seek(2)
+
+	p := b.Serialize()
+	assert.Equal(t, []byte{1, 0x03, 0, 0, 0, 2, 0, 0, 0, 3, 0x02, 0x01, 0x03}, p)
+
+	r, err := Deserialize(p)
+	assert.NoError(t, err)
+	assert.Equal(t, b.Bytes(), r.Bytes())
+	assert.Equal(t, b.State(), r.State())
+	assert.Equal(t, 3, r.Capacity())
+	p[10] = 0xFF
+	assert.Equal(t, uint8(0x02), r.PeekAbsU8(0))
+
+	s := NewBuffer(0)
+	s.SetStrictMode(true)
+	r, err = Deserialize(s.Serialize())
+	assert.NoError(t, err)
+	assert.Equal(t, s.State(), r.State())
+	assert.Equal(t, 0, r.Count())
+
+	// Malformed input
+	for _, in := range [][]byte{
+		{1, 0, 0, 0, 0, 0, 0, 0, 0},
+		{2, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{1, 0x08, 0, 0, 0, 0, 0, 0, 0, 0},
+		{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xAA},
+		{1, 0, 0, 0, 0, 2, 0, 0, 0, 1, 0xAA},
+	} {
+		_, err := Deserialize(in)
+		assert.Error(t, err, "in=%X", in)
+	}
+	_, err = Deserialize(nil)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+}
+
+// TestMarshalBinary tests the encoding.BinaryMarshaler round trip.
+func TestMarshalBinary(t *testing.T) {
+	b := NewBufferFrom([]byte{1, 2, 3})
+	b.Seek(1)
+	p, err := b.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, p)
+	p[0] = 9
+	assert.Equal(t, byte(1), b.Bytes()[0])
+
+	c := NewBuffer(0)
+	c.SetEndian(LittleEndian)
+	c.SetHLSwap(true)
+	c.Mark()
+	src := []byte{4, 5, 6, 7}
+	assert.NoError(t, c.UnmarshalBinary(src))
+	src[0] = 0
+	assert.Equal(t, []byte{4, 5, 6, 7}, c.Bytes())
+	assert.Equal(t, 0, c.Pos())
+	assert.Equal(t, 4, c.Capacity())
+	assert.Equal(t, LittleEndian, c.GetEndian())
+	assert.True(t, c.hlswap)
+	assert.Error(t, c.ResetToMark())
+
+	// Checksum and trace state from the old data is dropped
+	c = NewBufferFrom([]byte("old data"))
+	c.EnableRunningChecksum(crc32.NewIEEE())
+	c.EnableTrace()
+	c.TakeU32()
+	assert.NoError(t, c.UnmarshalBinary([]byte("new")))
+	assert.Empty(t, c.Trace())
+	c.TakeU8()
+	assert.Equal(t, crc32.ChecksumIEEE([]byte("n")), c.RunningChecksum())
+	assert.Len(t, c.Trace(), 1)
+
+	// Usable from encoders that check for encoding.BinaryMarshaler
+	type envelope struct {
+		ID   int
+		Body *Buffer
+	}
+	var wire bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&wire).Encode(envelope{ID: 7, Body: b}))
+	var out envelope
+	assert.NoError(t, gob.NewDecoder(&wire).Decode(&out))
+	assert.Equal(t, 7, out.ID)
+	assert.Equal(t, []byte{1, 2, 3}, out.Body.Bytes())
+}
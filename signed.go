@@ -0,0 +1,40 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+// PutI8 writes an int8 at the current position and advances the position.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutI8(v int8) { b.PutU8(uint8(v)) }
+
+// PutI16 writes an int16 as its two's complement uint16 at the current
+// position and advances the position.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutI16(v int16) { b.PutU16(uint16(v)) }
+
+// PutI32 writes an int32 as its two's complement uint32 at the current
+// position and advances the position. High-low swap applies as for PutU32.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutI32(v int32) { b.PutU32(uint32(v)) }
+
+// PutI64 writes an int64 as its two's complement uint64 at the current
+// position and advances the position. High-low swap applies as for PutU64.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutI64(v int64) { b.PutU64(uint64(v)) }
+
+// TakeI8 reads an int8 at the current position, then advances the position.
+func (b *Buffer) TakeI8() int8 { return int8(b.TakeU8()) }
+
+// TakeI16 reads a two's complement int16 at the current position, then
+// advances the position.
+func (b *Buffer) TakeI16() int16 { return int16(b.TakeU16()) }
+
+// TakeI32 reads a two's complement int32 at the current position, then
+// advances the position.
+func (b *Buffer) TakeI32() int32 { return int32(b.TakeU32()) }
+
+// TakeI64 reads a two's complement int64 at the current position, then
+// advances the position.
+func (b *Buffer) TakeI64() int64 { return int64(b.TakeU64()) }
@@ -0,0 +1,51 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSigned tests round-tripping signed integers.
+func TestSigned(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutI8(-1)
+	b.PutI16(-2)
+	b.PutI32(-3)
+	b.PutI64(-4)
+	b.PutI8(math.MinInt8)
+	b.PutI16(math.MaxInt16)
+	b.PutI32(math.MinInt32)
+	b.PutI64(math.MaxInt64)
+	assert.Equal(t, []byte{0xFF, 0xFF, 0xFE, 0xFF, 0xFF, 0xFF, 0xFD}, b.Bytes()[:7])
+
+	b.Rewind()
+	assert.Equal(t, int8(-1), b.TakeI8())
+	assert.Equal(t, int16(-2), b.TakeI16())
+	assert.Equal(t, int32(-3), b.TakeI32())
+	assert.Equal(t, int64(-4), b.TakeI64())
+	assert.Equal(t, int8(math.MinInt8), b.TakeI8())
+	assert.Equal(t, int16(math.MaxInt16), b.TakeI16())
+	assert.Equal(t, int32(math.MinInt32), b.TakeI32())
+	assert.Equal(t, int64(math.MaxInt64), b.TakeI64())
+
+	// Byte order and high-low swap apply as for the unsigned methods
+	b.Clear()
+	b.SetEndian(LittleEndian)
+	b.SetHLSwap(true)
+	b.PutI32(-0x01020304)
+	b.PutU32(uint32(0xFEFDFCFC))
+	b.Rewind()
+	assert.Equal(t, b.TakeU32(), b.TakeU32())
+
+	fixed := NewBuffer(3)
+	fixed.PutI16(1)
+	assert.Panics(t, func() { fixed.PutI16(1) })
+	assert.Panics(t, func() { fixed.TakeI32() })
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"fmt"
+)
+
+// Length prefix selectors used by PutSizedBytes and TakeSizedBytes.
+const (
+	sizedMax8     = 0xFC // largest length encoded in the selector byte itself
+	sizedSel16    = 0xFD // selector followed by a u16 length
+	sizedSel32    = 0xFE // selector followed by a u32 length
+	sizedReserved = 0xFF // reserved selector
+	sizedMax32    = 0xFFFFFFFF
+)
+
+// sizedPrefixLen returns the length of the prefix needed for n bytes.
+func sizedPrefixLen(n int) int {
+	switch {
+	case n <= sizedMax8:
+		return 1
+	case n <= 0xFFFF:
+		return 3
+	default:
+		return 5
+	}
+}
+
+// PutSizedBytes writes v preceded by the smallest length prefix that fits,
+// then advances the position. The prefix scheme is:
+//
+//	length <= 0xFC:        [length:u8]
+//	length <= 0xFFFF:      [0xFD] [length:u16]
+//	length <= 0xFFFFFFFF:  [0xFE] [length:u32]
+//
+// Multi-byte lengths follow the buffer's byte order (and high-low swap for
+// u32). The selector 0xFF is reserved.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutSizedBytes(v []byte) {
+	n := len(v)
+	if uint64(n) > sizedMax32 {
+		b.fail(fmt.Errorf("mbuff.Buffer.PutSizedBytes: length %d exceeds %d", n, uint64(sizedMax32)))
+		return
+	}
+	prefixLen := sizedPrefixLen(n)
+	required := b.pos + prefixLen + n
+	if !b.checkWritable("PutSizedBytes", required) {
+		return
+	}
+
+	b.traceOp("PutSizedBytes", prefixLen+n, 0)
+	switch prefixLen {
+	case 1:
+		b.data[b.pos] = uint8(n)
+	case 3:
+		b.data[b.pos] = sizedSel16
+		b.order.PutUint16(b.data[b.pos+1:], uint16(n))
+	default:
+		b.data[b.pos] = sizedSel32
+		b.order.PutUint32(b.data[b.pos+1:], b.HLSwap32(uint32(n)))
+	}
+	b.pos += prefixLen
+	b.pos += copy(b.data[b.pos:], v)
+}
+
+// TakeSizedBytes reads a value written by PutSizedBytes into a new slice,
+// then advances the position. The position is left unchanged if the prefix
+// is malformed or the value is incomplete.
+func (b *Buffer) TakeSizedBytes() []byte {
+	if !b.checkReadable(1) {
+		return nil
+	}
+	prefixLen, n := 1, 0
+	switch sel := b.data[b.pos]; sel {
+	case sizedSel16:
+		prefixLen = 3
+		if !b.checkReadable(prefixLen) {
+			return nil
+		}
+		n = int(b.order.Uint16(b.data[b.pos+1:]))
+	case sizedSel32:
+		prefixLen = 5
+		if !b.checkReadable(prefixLen) {
+			return nil
+		}
+		n = int(b.HLSwap32(b.order.Uint32(b.data[b.pos+1:])))
+	case sizedReserved:
+		b.fail(fmt.Errorf("mbuff.Buffer.TakeSizedBytes: reserved selector 0x%02X at pos %d", sel, b.pos))
+		return nil
+	default:
+		n = int(sel)
+	}
+	if !b.checkReadable(prefixLen + n) {
+		return nil
+	}
+
+	v := make([]byte, n)
+	copy(v, b.data[b.pos+prefixLen:])
+	b.traceOp("TakeSizedBytes", prefixLen+n, 0)
+	b.pos += prefixLen + n
+	return v
+}
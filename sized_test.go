@@ -0,0 +1,76 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSizedBytes tests the self-describing length prefix encoding.
+func TestSizedBytes(t *testing.T) {
+	small := []byte{0x01, 0x02, 0x03}
+	edge := bytes.Repeat([]byte{0xAA}, 0xFC)
+	medium := bytes.Repeat([]byte{0xBB}, 0xFD)
+	large := bytes.Repeat([]byte{0xCC}, 0x10000)
+
+	b := NewBuilder(0)
+	b.PutSizedBytes(nil)
+	b.PutSizedBytes(small)
+	b.PutSizedBytes(edge)
+	b.PutSizedBytes(medium)
+	b.PutSizedBytes(large)
+	assert.Equal(t, 1+(1+3)+(1+0xFC)+(3+0xFD)+(5+0x10000), b.Count())
+
+	// Check the prefixes
+	assert.Equal(t, uint8(0x00), b.PeekAbsU8(0))
+	assert.Equal(t, uint8(0x03), b.PeekAbsU8(1))
+	assert.Equal(t, uint8(0xFC), b.PeekAbsU8(5))
+	assert.Equal(t, uint8(0xFD), b.PeekAbsU8(5+1+0xFC))
+	assert.Equal(t, uint16(0xFD), b.PeekAbsU16(5+1+0xFC+1))
+
+	b.Rewind()
+	assert.Equal(t, []byte{}, b.TakeSizedBytes())
+	assert.Equal(t, small, b.TakeSizedBytes())
+	assert.Equal(t, edge, b.TakeSizedBytes())
+	assert.Equal(t, medium, b.TakeSizedBytes())
+	assert.Equal(t, large, b.TakeSizedBytes())
+	assert.Equal(t, 0, b.Readable())
+
+	// Little endian prefix
+	b.Clear()
+	b.SetEndian(LittleEndian)
+	b.PutSizedBytes(medium)
+	assert.Equal(t, []byte{0xFD, 0xFD, 0x00}, b.Bytes()[:3])
+	b.Rewind()
+	assert.Equal(t, medium, b.TakeSizedBytes())
+}
+
+// TestSizedBytes_Invalid tests rejecting truncated or malformed input.
+func TestSizedBytes_Invalid(t *testing.T) {
+	// Truncated value leaves pos unchanged
+	b := NewBufferFrom([]byte{0x05, 0x01, 0x02})
+	assert.Panics(t, func() { b.TakeSizedBytes() })
+	assert.Equal(t, 0, b.Pos())
+
+	// Truncated prefix
+	b = NewBufferFrom([]byte{0xFE, 0x00, 0x00})
+	assert.Panics(t, func() { b.TakeSizedBytes() })
+
+	// Reserved selector
+	b = NewBufferFrom([]byte{0xFF, 0x00})
+	b.SetStrictMode(true)
+	assert.Nil(t, b.TakeSizedBytes())
+	assert.Error(t, b.Err())
+	assert.Equal(t, 0, b.Pos())
+
+	// Overflow writes nothing
+	b = NewBuffer(4)
+	assert.Panics(t, func() { b.PutSizedBytes([]byte{1, 2, 3, 4}) })
+	assert.Equal(t, 0, b.Count())
+}
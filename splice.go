@@ -0,0 +1,78 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+// movePositions applies move to the position and to every offset recorded
+// against the data: the running checksum's progress, named marks and pushed
+// marks. The running checksum must already be synced.
+func (b *Buffer) movePositions(move func(int) int) {
+	b.pos = move(b.pos)
+	b.csPos = move(b.csPos)
+	for name, p := range b.marks {
+		b.marks[name] = move(p)
+	}
+	for i, p := range b.pushed {
+		b.pushed[i] = move(p)
+	}
+}
+
+// insertMove returns how an offset moves when n bytes are inserted at offset.
+func insertMove(offset, n int) func(int) int {
+	return func(p int) int {
+		if p >= offset {
+			return p + n
+		}
+		return p
+	}
+}
+
+// deleteMove returns how an offset moves when the n bytes at offset are
+// deleted.
+func deleteMove(offset, n int) func(int) int {
+	return func(p int) int {
+		switch {
+		case p >= offset+n:
+			return p - n
+		case p > offset:
+			return offset
+		}
+		return p
+	}
+}
+
+// Insert splices data into the valid data at offset, shifting [offset:len]
+// right by len(data) and extending the count. The position, and any mark,
+// moves with the bytes if it is at or after offset; bytes consumed for the
+// running checksum are hashed beforehand. data must not alias the buffer.
+// Panics if offset is outside [0, len] or the result would exceed the
+// buffer's capacity, unless error mode is enabled.
+func (b *Buffer) Insert(offset int, data []byte) {
+	if !b.checkRange("Insert", offset, offset) {
+		return
+	}
+	count := len(b.data)
+	if !b.checkWritable("Insert", count+len(data)) {
+		return
+	}
+	copy(b.data[offset+len(data):], b.data[offset:count])
+	copy(b.data[offset:], data)
+	b.movePositions(insertMove(offset, len(data)))
+}
+
+// Delete removes the n bytes at offset from the valid data, shifting the
+// tail left and reducing the count. A position or mark inside the removed
+// bytes moves to offset; one after them moves back by n. Bytes consumed for
+// the running checksum are hashed beforehand. The capacity is unchanged.
+// Panics if [offset, offset+n) is not within the valid data, unless error
+// mode is enabled.
+func (b *Buffer) Delete(offset, n int) {
+	if !b.checkRange("Delete", offset, offset+n) {
+		return
+	}
+	copy(b.data[offset:], b.data[offset+n:])
+	b.data = b.data[:len(b.data)-n]
+	b.movePositions(deleteMove(offset, n))
+}
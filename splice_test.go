@@ -0,0 +1,115 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"hash/crc32"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestInsert tests splicing bytes into the valid data.
+func TestInsert(t *testing.T) {
+	b := NewBuffer(8)
+	b.PutArr8([]byte{1, 2, 5})
+	b.Insert(2, []byte{3, 4})
+	assert.Equal(t, []byte{1, 2, 3, 4, 5}, b.Bytes())
+	assert.Equal(t, 5, b.Pos())
+
+	// A position before offset stays put
+	b.Seek(1)
+	b.Insert(5, []byte{6})
+	b.Insert(0, nil)
+	assert.Equal(t, []byte{1, 2, 3, 4, 5, 6}, b.Bytes())
+	assert.Equal(t, 1, b.Pos())
+
+	// A position at offset moves with the bytes
+	b.Insert(1, []byte{0xAA})
+	assert.Equal(t, 2, b.Pos())
+	assert.Equal(t, uint8(2), b.TakeU8())
+
+	// Nothing changes on failure
+	assert.Panics(t, func() { b.Insert(8, []byte{0}) })
+	assert.Panics(t, func() { b.Insert(0, []byte{0, 0}) })
+	assert.Equal(t, []byte{1, 0xAA, 2, 3, 4, 5, 6}, b.Bytes())
+
+	// The Builder grows to fit
+	w := NewBuilder(0)
+	w.PutU16(0x0304)
+	w.Insert(0, make([]byte, 100))
+	assert.Equal(t, 102, w.Count())
+	assert.Equal(t, 102, w.Pos())
+	assert.Equal(t, []byte{0x03, 0x04}, w.Bytes()[100:])
+}
+
+// TestDelete tests removing bytes from the valid data.
+func TestDelete(t *testing.T) {
+	b := NewBufferFrom([]byte{1, 2, 3, 4, 5, 6})
+	b.Seek(5)
+	b.Delete(1, 2)
+	assert.Equal(t, []byte{1, 4, 5, 6}, b.Bytes())
+	assert.Equal(t, 3, b.Pos())
+	assert.Equal(t, 6, b.Capacity())
+
+	// A position inside the removed bytes moves to offset
+	b.Delete(2, 2)
+	assert.Equal(t, []byte{1, 4}, b.Bytes())
+	assert.Equal(t, 2, b.Pos())
+	b.Seek(1)
+	b.Delete(1, 1)
+	assert.Equal(t, 1, b.Pos())
+	b.Delete(0, 0)
+	assert.Equal(t, []byte{1}, b.Bytes())
+
+	assert.Panics(t, func() { b.Delete(0, 2) })
+	assert.Panics(t, func() { b.Delete(1, -1) })
+
+	b.SetStrictMode(true)
+	b.Delete(-1, 1)
+	assert.ErrorIs(t, b.Err(), ErrOutOfRange)
+	assert.Equal(t, []byte{1}, b.Bytes())
+}
+
+// TestSplicePositions tests that splices keep the running checksum and marks
+// in step with the data.
+func TestSplicePositions(t *testing.T) {
+	b := NewBuffer(8)
+	b.PutArr8([]byte{1, 2, 3, 4, 5, 6})
+	b.Rewind()
+	b.EnableRunningChecksum(crc32.NewIEEE())
+	b.TakeU32()
+	b.Delete(0, 2)
+	b.TakeU16()
+	assert.Equal(t, crc32.ChecksumIEEE([]byte{1, 2, 3, 4, 5, 6}), b.RunningChecksum())
+
+	b.Seek(1)
+	b.ResetRunningChecksum()
+	b.TakeU8()
+	b.Insert(0, []byte{0xA, 0xB})
+	assert.Equal(t, 4, b.Pos())
+	b.TakeU8()
+	assert.Equal(t, crc32.ChecksumIEEE([]byte{4, 5}), b.RunningChecksum())
+
+	// Marks follow the bytes they point at
+	b = NewBuffer(8)
+	b.PutArr8([]byte{1, 2, 3, 4, 5, 6})
+	b.Seek(1)
+	b.MarkNamed("one")
+	b.Seek(4)
+	b.Mark()
+	b.Seek(5)
+	b.MarkNamed("five")
+	b.Insert(2, []byte{0xA, 0xB})
+	b.Delete(3, 2)
+	assert.Equal(t, []byte{1, 2, 0xA, 4, 5, 6}, b.Bytes())
+	assert.NoError(t, b.ResetToMark())
+	assert.Equal(t, byte(5), b.TakeU8())
+	assert.NoError(t, b.SeekMark("one"))
+	assert.Equal(t, byte(2), b.TakeU8())
+	assert.NoError(t, b.SeekMark("five"))
+	assert.Equal(t, byte(6), b.TakeU8())
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"fmt"
+)
+
+// BufferState is a snapshot of a buffer's cursor and settings, taken by
+// State and restored by SetState. It does not include the data itself.
+type BufferState struct {
+	Pos        int    // current position
+	Endian     Endian // byte order
+	HLSwap     bool   // whether high-low swap is enabled
+	StrictMode bool   // whether bounds violations are recorded instead of panicking
+}
+
+// State returns a snapshot of the current position and settings.
+func (b *Buffer) State() BufferState {
+	return BufferState{
+		Pos:        b.pos,
+		Endian:     b.GetEndian(),
+		HLSwap:     b.hlswap,
+		StrictMode: b.errMode,
+	}
+}
+
+// SetState restores a snapshot taken by State.
+// Returns an error, without changing anything, if the snapshot's position
+// exceeds the current count.
+func (b *Buffer) SetState(s BufferState) error {
+	if s.Pos < 0 || s.Pos > len(b.data) {
+		return fmt.Errorf("mbuff.Buffer.SetState: state pos %d out of bounds [0, %d]", s.Pos, len(b.data))
+	}
+	b.setPos(s.Pos)
+	b.SetEndian(s.Endian)
+	b.hlswap = s.HLSwap
+	b.errMode = s.StrictMode
+	return nil
+}
+
+// MarkNamed records the current position under name, replacing any previous
+// mark of that name, so that SeekMark can return to it later in any order.
+func (b *Buffer) MarkNamed(name string) {
+	if b.marks == nil {
+		b.marks = make(map[string]int)
+	}
+	b.marks[name] = b.pos
+}
+
+// SeekMark moves the position to the mark recorded under name.
+// Returns an error, without moving, if no such mark exists or the marked
+// position now exceeds the count.
+func (b *Buffer) SeekMark(name string) error {
+	pos, ok := b.marks[name]
+	if !ok {
+		return fmt.Errorf("mbuff.Buffer.SeekMark: unknown mark %q", name)
+	}
+	if pos > len(b.data) {
+		return fmt.Errorf("mbuff.Buffer.SeekMark: mark %q at %d exceeds count %d", name, pos, len(b.data))
+	}
+	b.setPos(pos)
+	return nil
+}
+
+// Mark pushes the current position onto a stack of marks, so that
+// ResetToMark can return to it after reading ahead. Marks nest: each
+// ResetToMark or Unmark pops the most recent one.
+func (b *Buffer) Mark() {
+	b.pushed = append(b.pushed, b.pos)
+}
+
+// ResetToMark pops the most recent mark pushed by Mark and moves the
+// position back to it. (Reset itself is the bytes.Buffer-compatible alias of
+// Clear.) Returns an error, without moving, if there is no mark or the marked
+// position now exceeds the count; the mark is popped either way.
+func (b *Buffer) ResetToMark() error {
+	if len(b.pushed) == 0 {
+		return fmt.Errorf("mbuff.Buffer.ResetToMark: no mark")
+	}
+	pos := b.pushed[len(b.pushed)-1]
+	b.pushed = b.pushed[:len(b.pushed)-1]
+	if pos > len(b.data) {
+		return fmt.Errorf("mbuff.Buffer.ResetToMark: mark at %d exceeds count %d", pos, len(b.data))
+	}
+	b.setPos(pos)
+	return nil
+}
+
+// Unmark pops the most recent mark pushed by Mark without moving, e.g. once
+// the data read ahead is accepted.
+// Returns an error if there is no mark.
+func (b *Buffer) Unmark() error {
+	if len(b.pushed) == 0 {
+		return fmt.Errorf("mbuff.Buffer.Unmark: no mark")
+	}
+	b.pushed = b.pushed[:len(b.pushed)-1]
+	return nil
+}
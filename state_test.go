@@ -0,0 +1,106 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestState tests saving and restoring the cursor and settings.
+func TestState(t *testing.T) {
+	b := NewBuffer(16)
+	b.PutU32(0x11223344)
+	b.Seek(2)
+	b.SetHLSwap(true)
+
+	s := b.State()
+	assert.Equal(t, BufferState{Pos: 2, Endian: BigEndian, HLSwap: true}, s)
+
+	b.SetEndian(LittleEndian)
+	b.SetHLSwap(false)
+	b.SetStrictMode(true)
+	b.PutU32(0x55667788)
+	assert.NoError(t, b.SetState(s))
+	assert.Equal(t, 2, b.Pos())
+	assert.Equal(t, BigEndian, b.GetEndian())
+	assert.Equal(t, s, b.State())
+
+	// Position beyond the current count is rejected
+	b.Clear()
+	assert.Error(t, b.SetState(s))
+	assert.Equal(t, 0, b.Pos())
+	assert.Error(t, b.SetState(BufferState{Pos: -1}))
+}
+
+// TestNamedMarks tests jumping between named positions.
+func TestNamedMarks(t *testing.T) {
+	b := NewBufferFrom([]byte{1, 2, 3, 4, 5, 6})
+	assert.ErrorContains(t, b.SeekMark("header"), `unknown mark "header"`)
+
+	b.MarkNamed("header")
+	b.Skip(2)
+	b.MarkNamed("body")
+	b.Skip(3)
+
+	assert.NoError(t, b.SeekMark("header"))
+	assert.Equal(t, uint8(1), b.TakeU8())
+	assert.NoError(t, b.SeekMark("body"))
+	assert.Equal(t, uint8(3), b.TakeU8())
+
+	// Re-marking replaces the position
+	b.MarkNamed("header")
+	b.Rewind()
+	assert.NoError(t, b.SeekMark("header"))
+	assert.Equal(t, 3, b.Pos())
+
+	// Marks beyond a truncated count are rejected
+	b.Truncate(1)
+	assert.ErrorContains(t, b.SeekMark("body"), "mark \"body\" at 2 exceeds count 1")
+	assert.Equal(t, 1, b.Pos())
+}
+
+// TestMarkStack tests nested marks for reading ahead.
+func TestMarkStack(t *testing.T) {
+	b := NewBufferFrom([]byte{1, 2, 3, 4, 5, 6})
+	assert.EqualError(t, b.ResetToMark(), "mbuff.Buffer.ResetToMark: no mark")
+	assert.EqualError(t, b.Unmark(), "mbuff.Buffer.Unmark: no mark")
+
+	b.Mark()
+	b.Skip(2)
+	b.Mark()
+	b.Skip(2)
+	assert.NoError(t, b.ResetToMark())
+	assert.Equal(t, 2, b.Pos())
+	assert.NoError(t, b.ResetToMark())
+	assert.Equal(t, 0, b.Pos())
+	assert.Error(t, b.ResetToMark())
+
+	// Unmark accepts the read-ahead without moving
+	b.Mark()
+	b.Skip(3)
+	b.Mark()
+	b.Skip(1)
+	assert.NoError(t, b.Unmark())
+	assert.Equal(t, 4, b.Pos())
+	assert.NoError(t, b.ResetToMark())
+	assert.Equal(t, 0, b.Pos())
+
+	// Marks beyond a truncated count are popped but rejected
+	b.Seek(5)
+	b.Mark()
+	b.Truncate(2)
+	assert.ErrorContains(t, b.ResetToMark(), "mark at 5 exceeds count 2")
+	assert.Equal(t, 2, b.Pos())
+	assert.Error(t, b.Unmark())
+
+	// Reset remains the bytes.Buffer-compatible Clear
+	b.Mark()
+	b.Reset()
+	assert.Equal(t, 0, b.Count())
+	assert.NoError(t, b.Unmark())
+}
@@ -6,59 +6,252 @@
 package mbuff
 
 import (
+	"bytes"
 	"fmt"
+	"unicode/utf8"
 )
 
-// mustHaveReadable checks if the current position and length are within the count.
-func (b *Buffer) mustHaveReadable(n int) {
+// checkReadable checks if the current position and length are within the count.
+func (b *Buffer) checkReadable(n int) bool {
+	if b.err != nil {
+		return false
+	}
 	if b.pos+n > len(b.data) {
-		panic(fmt.Errorf("mbuff.Buffer.mustHaveReadable: read of %d bytes at pos %d exceeds count %d", n, b.pos, len(b.data)))
+		return b.fail(fmt.Errorf("mbuff.Buffer.checkReadable: read of %d bytes at pos %d exceeds count %d: %w", n, b.pos, len(b.data), ErrOutOfRange))
 	}
+	return true
 }
 
 // TakeU8 reads and returns a uint8 at the current position, then advances the position.
 func (b *Buffer) TakeU8() uint8 {
-	b.mustHaveReadable(1)
+	if !b.checkReadable(1) {
+		return 0
+	}
 	v := b.data[b.pos]
+	b.traceOp("TakeU8", 1, uint64(v))
 	b.pos += 1
 	return v
 }
 
 // TakeU16 reads and returns a uint16 at the current position, then advances the position.
 func (b *Buffer) TakeU16() uint16 {
-	b.mustHaveReadable(2)
+	if !b.checkReadable(2) {
+		return 0
+	}
 	v := b.order.Uint16(b.data[b.pos : b.pos+2])
+	b.traceOp("TakeU16", 2, uint64(v))
 	b.pos += 2
 	return v
 }
 
 // TakeU32 reads and returns a uint32 at the current position, then advances the position.
 func (b *Buffer) TakeU32() uint32 {
-	b.mustHaveReadable(4)
-	v := b.order.Uint32(b.data[b.pos : b.pos+4])
+	if !b.checkReadable(4) {
+		return 0
+	}
+	v := b.HLSwap32(b.order.Uint32(b.data[b.pos : b.pos+4]))
+	b.traceOp("TakeU32", 4, uint64(v))
 	b.pos += 4
-	return b.HLSwap32(v)
+	return v
 }
 
 // TakeU64 reads and returns a uint64 at the current position, then advances the position.
 func (b *Buffer) TakeU64() uint64 {
-	b.mustHaveReadable(8)
-	v := b.order.Uint64(b.data[b.pos : b.pos+8])
+	if !b.checkReadable(8) {
+		return 0
+	}
+	v := b.HLSwap64(b.order.Uint64(b.data[b.pos : b.pos+8]))
+	b.traceOp("TakeU64", 8, v)
 	b.pos += 8
-	return b.HLSwap64(v)
+	return v
 }
 
 // TakeArr8 reads bytes at the current position into slice v, then advances the position.
 func (b *Buffer) TakeArr8(v []byte) {
-	b.mustHaveReadable(len(v))
+	if !b.checkReadable(len(v)) {
+		return
+	}
+	b.traceOp("TakeArr8", len(v), 0)
 	n := copy(v, b.data[b.pos:])
 	b.pos += n
 }
 
+// TakeBytesInto reads length bytes at the current position into scratch,
+// reallocating it only if its capacity is smaller than length, and returns
+// scratch[:length]. It then advances the position. This lets a decode loop
+// reuse one slice for variable-length fields.
+func (b *Buffer) TakeBytesInto(length int, scratch []byte) []byte {
+	if length < 0 {
+		b.fail(fmt.Errorf("mbuff.Buffer.TakeBytesInto: negative length %d", length))
+		return scratch[:0]
+	}
+	if !b.checkReadable(length) {
+		return scratch[:0]
+	}
+	if cap(scratch) < length {
+		scratch = make([]byte, length)
+	}
+	scratch = scratch[:length]
+	copy(scratch, b.data[b.pos:])
+	b.pos += length
+	return scratch
+}
+
+// readableRange checks if n bytes are readable at the current position,
+// returning an error instead of panicking regardless of the failure mode.
+func (b *Buffer) readableRange(method string, n int) error {
+	if b.pos+n > len(b.data) {
+		return fmt.Errorf("mbuff.Buffer.%s: read of %d bytes at pos %d exceeds count %d: %w", method, n, b.pos, len(b.data), ErrOutOfRange)
+	}
+	return nil
+}
+
+// TryTakeU8 reads a uint8 at the current position, then advances the position.
+// It returns an error wrapping ErrOutOfRange instead of panicking, without
+// advancing, and does not record it in error mode.
+func (b *Buffer) TryTakeU8() (uint8, error) {
+	if err := b.readableRange("TryTakeU8", 1); err != nil {
+		return 0, err
+	}
+	v := b.data[b.pos]
+	b.traceOp("TryTakeU8", 1, uint64(v))
+	b.pos++
+	return v, nil
+}
+
+// TryTakeU16 reads a uint16 at the current position, then advances the
+// position. It returns an error wrapping ErrOutOfRange instead of panicking,
+// without advancing, and does not record it in error mode.
+func (b *Buffer) TryTakeU16() (uint16, error) {
+	if err := b.readableRange("TryTakeU16", 2); err != nil {
+		return 0, err
+	}
+	v := b.order.Uint16(b.data[b.pos:])
+	b.traceOp("TryTakeU16", 2, uint64(v))
+	b.pos += 2
+	return v, nil
+}
+
+// TryTakeU32 reads a uint32 at the current position, then advances the
+// position. It returns an error wrapping ErrOutOfRange instead of panicking,
+// without advancing, and does not record it in error mode.
+func (b *Buffer) TryTakeU32() (uint32, error) {
+	if err := b.readableRange("TryTakeU32", 4); err != nil {
+		return 0, err
+	}
+	v := b.HLSwap32(b.order.Uint32(b.data[b.pos:]))
+	b.traceOp("TryTakeU32", 4, uint64(v))
+	b.pos += 4
+	return v, nil
+}
+
+// TryTakeU64 reads a uint64 at the current position, then advances the
+// position. It returns an error wrapping ErrOutOfRange instead of panicking,
+// without advancing, and does not record it in error mode.
+func (b *Buffer) TryTakeU64() (uint64, error) {
+	if err := b.readableRange("TryTakeU64", 8); err != nil {
+		return 0, err
+	}
+	v := b.HLSwap64(b.order.Uint64(b.data[b.pos:]))
+	b.traceOp("TryTakeU64", 8, v)
+	b.pos += 8
+	return v, nil
+}
+
+// TryTakeArr8 reads bytes at the current position into slice v, then
+// advances the position. It returns an error wrapping ErrOutOfRange instead
+// of panicking, without reading or advancing, and does not record it in
+// error mode.
+func (b *Buffer) TryTakeArr8(v []byte) error {
+	if err := b.readableRange("TryTakeArr8", len(v)); err != nil {
+		return err
+	}
+	b.traceOp("TryTakeArr8", len(v), 0)
+	b.pos += copy(v, b.data[b.pos:])
+	return nil
+}
+
+// TakeStr reads n bytes at the current position into a new string, then advances the position.
+func (b *Buffer) TakeStr(n int) string {
+	if n < 0 {
+		b.fail(fmt.Errorf("mbuff.Buffer.TakeStr: negative length %d", n))
+		return ""
+	}
+	if !b.checkReadable(n) {
+		return ""
+	}
+	v := string(b.data[b.pos : b.pos+n])
+	b.traceOp("TakeStr", n, 0)
+	b.pos += n
+	return v
+}
+
+// decodeRune decodes a UTF-8 rune at absolute position p and returns it with
+// its length in bytes, or 0, 0 after recording the failure if the encoding is
+// truncated or invalid.
+func (b *Buffer) decodeRune(method string, p int) (rune, int) {
+	r, n := utf8.DecodeRune(b.data[p:])
+	if r == utf8.RuneError && n <= 1 {
+		if !utf8.FullRune(b.data[p:]) {
+			b.fail(fmt.Errorf("mbuff.Buffer.%s: truncated UTF-8 at pos %d: %w", method, p, ErrOutOfRange))
+		} else {
+			b.fail(fmt.Errorf("mbuff.Buffer.%s: invalid UTF-8 at pos %d", method, p))
+		}
+		return 0, 0
+	}
+	return r, n
+}
+
+// TakeRune reads a UTF-8 encoded rune and returns it with the number of bytes
+// consumed, then advances the position. The position is left unchanged, and
+// 0, 0 returned, if the encoding is truncated or invalid.
+func (b *Buffer) TakeRune() (rune, int) {
+	if b.err != nil {
+		return 0, 0
+	}
+	r, n := b.decodeRune("TakeRune", b.pos)
+	b.pos += n
+	return r, n
+}
+
+// decodeCStr decodes a NUL-terminated string at absolute position p and
+// returns it with its length in bytes including the terminator, or "", 0
+// after recording the failure if there is no terminator.
+func (b *Buffer) decodeCStr(method string, p int) (string, int) {
+	i := bytes.IndexByte(b.data[p:], 0)
+	if i < 0 {
+		b.fail(fmt.Errorf("mbuff.Buffer.%s: NUL terminator not found after pos %d: %w", method, p, ErrOutOfRange))
+		return "", 0
+	}
+	return string(b.data[p : p+i]), i + 1
+}
+
+// TakeCStr reads a NUL-terminated string and returns it, without the
+// terminator, with the number of bytes consumed including the terminator.
+// It then advances the position. The position is left unchanged, and "", 0
+// returned, if the readable region ends before a NUL.
+func (b *Buffer) TakeCStr() (string, int) {
+	if b.err != nil {
+		return "", 0
+	}
+	s, n := b.decodeCStr("TakeCStr", b.pos)
+	b.pos += n
+	return s, n
+}
+
 // TakeArr16 reads uint16 values at the current position into slice v, then advances the position.
 func (b *Buffer) TakeArr16(v []uint16) {
 	byteLen := len(v) << 1
-	b.mustHaveReadable(byteLen)
+	if !b.checkReadable(byteLen) {
+		return
+	}
+	b.traceOp("TakeArr16", byteLen, 0)
+	if b.isHostOrder() {
+		copy(bytesOf16(v), b.data[b.pos:])
+		b.pos += byteLen
+		return
+	}
+
 	readPos := b.pos
 	for i := range v {
 		v[i] = b.order.Uint16(b.data[readPos : readPos+2])
@@ -70,7 +263,16 @@ func (b *Buffer) TakeArr16(v []uint16) {
 // TakeArr32 reads uint32 values at the current position into slice v, then advances the position.
 func (b *Buffer) TakeArr32(v []uint32) {
 	byteLen := len(v) << 2
-	b.mustHaveReadable(byteLen)
+	if !b.checkReadable(byteLen) {
+		return
+	}
+	b.traceOp("TakeArr32", byteLen, 0)
+	if b.isHostLayout() {
+		copy(bytesOf32(v), b.data[b.pos:])
+		b.pos += byteLen
+		return
+	}
+
 	readPos := b.pos
 	for i := range v {
 		val := b.order.Uint32(b.data[readPos : readPos+4])
@@ -83,7 +285,16 @@ func (b *Buffer) TakeArr32(v []uint32) {
 // TakeArr64 reads uint64 values at the current position into slice v, then advances the position.
 func (b *Buffer) TakeArr64(v []uint64) {
 	byteLen := len(v) << 3
-	b.mustHaveReadable(byteLen)
+	if !b.checkReadable(byteLen) {
+		return
+	}
+	b.traceOp("TakeArr64", byteLen, 0)
+	if b.isHostLayout() {
+		copy(bytesOf64(v), b.data[b.pos:])
+		b.pos += byteLen
+		return
+	}
+
 	readPos := b.pos
 	for i := range v {
 		val := b.order.Uint64(b.data[readPos : readPos+8])
@@ -92,3 +303,190 @@ func (b *Buffer) TakeArr64(v []uint64) {
 	}
 	b.pos += byteLen
 }
+
+// TakeArr32Func reads uint32 values at the current position into slice v,
+// storing each value transformed by fn, then advances the position.
+func (b *Buffer) TakeArr32Func(v []uint32, fn func(uint32) uint32) {
+	byteLen := len(v) << 2
+	if !b.checkReadable(byteLen) {
+		return
+	}
+
+	readPos := b.pos
+	for i := range v {
+		val := b.order.Uint32(b.data[readPos : readPos+4])
+		v[i] = fn(b.HLSwap32(val))
+		readPos += 4
+	}
+	b.pos += byteLen
+}
+
+// TakeU16In reads a uint16 in byte order e at the current position, then advances
+// the position, without changing the buffer's byte order.
+func (b *Buffer) TakeU16In(e Endian) uint16 {
+	if !b.checkReadable(2) {
+		return 0
+	}
+	v := e.byteOrder().Uint16(b.data[b.pos : b.pos+2])
+	b.traceOp("TakeU16In", 2, uint64(v))
+	b.pos += 2
+	return v
+}
+
+// TakeU32In reads a uint32 in byte order e at the current position, then advances
+// the position, without changing the buffer's byte order. High-low swap still applies.
+func (b *Buffer) TakeU32In(e Endian) uint32 {
+	if !b.checkReadable(4) {
+		return 0
+	}
+	v := b.HLSwap32(e.byteOrder().Uint32(b.data[b.pos : b.pos+4]))
+	b.traceOp("TakeU32In", 4, uint64(v))
+	b.pos += 4
+	return v
+}
+
+// TakeU64In reads a uint64 in byte order e at the current position, then advances
+// the position, without changing the buffer's byte order. High-low swap still applies.
+func (b *Buffer) TakeU64In(e Endian) uint64 {
+	if !b.checkReadable(8) {
+		return 0
+	}
+	v := b.HLSwap64(e.byteOrder().Uint64(b.data[b.pos : b.pos+8]))
+	b.traceOp("TakeU64In", 8, v)
+	b.pos += 8
+	return v
+}
+
+// expectError describes a field that does not hold the expected value.
+func expectError(method string, pos, digits int, want, got uint64) error {
+	return fmt.Errorf("mbuff.Buffer.%s: field at offset %d: expected 0x%0*X, got 0x%0*X", method, pos, digits, want, digits, got)
+}
+
+// ExpectU8 reads a uint8 at the current position and advances the position if
+// it equals want. Otherwise it returns an error, without advancing.
+func (b *Buffer) ExpectU8(want uint8) error {
+	if !b.checkReadable(1) {
+		return b.err
+	}
+	if got := b.data[b.pos]; got != want {
+		return expectError("ExpectU8", b.pos, 2, uint64(want), uint64(got))
+	}
+	b.traceOp("ExpectU8", 1, uint64(want))
+	b.pos += 1
+	return nil
+}
+
+// ExpectU16 reads a uint16 at the current position and advances the position
+// if it equals want. Otherwise it returns an error, without advancing.
+func (b *Buffer) ExpectU16(want uint16) error {
+	if !b.checkReadable(2) {
+		return b.err
+	}
+	if got := b.order.Uint16(b.data[b.pos:]); got != want {
+		return expectError("ExpectU16", b.pos, 4, uint64(want), uint64(got))
+	}
+	b.traceOp("ExpectU16", 2, uint64(want))
+	b.pos += 2
+	return nil
+}
+
+// ExpectU32 reads a uint32 at the current position and advances the position
+// if it equals want. Otherwise it returns an error, without advancing.
+func (b *Buffer) ExpectU32(want uint32) error {
+	if !b.checkReadable(4) {
+		return b.err
+	}
+	if got := b.HLSwap32(b.order.Uint32(b.data[b.pos:])); got != want {
+		return expectError("ExpectU32", b.pos, 8, uint64(want), uint64(got))
+	}
+	b.traceOp("ExpectU32", 4, uint64(want))
+	b.pos += 4
+	return nil
+}
+
+// ExpectU64 reads a uint64 at the current position and advances the position
+// if it equals want. Otherwise it returns an error, without advancing.
+func (b *Buffer) ExpectU64(want uint64) error {
+	if !b.checkReadable(8) {
+		return b.err
+	}
+	if got := b.HLSwap64(b.order.Uint64(b.data[b.pos:])); got != want {
+		return expectError("ExpectU64", b.pos, 16, want, got)
+	}
+	b.traceOp("ExpectU64", 8, want)
+	b.pos += 8
+	return nil
+}
+
+// ExpectEnd returns an error reporting the number of leftover bytes unless all
+// data has been read, so decoders can reject trailing data. It returns the
+// recorded error if one is set in error mode.
+func (b *Buffer) ExpectEnd() error {
+	if b.err != nil {
+		return b.err
+	}
+	if n := b.Readable(); n > 0 {
+		return fmt.Errorf("mbuff.Buffer.ExpectEnd: %d trailing bytes at pos %d", n, b.pos)
+	}
+	return nil
+}
+
+// TakeU16Until reads uint16 values at the current position until one equals
+// sentinel, then advances the position past the sentinel and returns the
+// values before it, which may be empty. The position is left unchanged if
+// the readable region ends before the sentinel.
+func (b *Buffer) TakeU16Until(sentinel uint16) []uint16 {
+	if b.err != nil {
+		return nil
+	}
+	n := -1
+	for p := b.pos; p+2 <= len(b.data); p += 2 {
+		if b.order.Uint16(b.data[p:]) == sentinel {
+			n = (p - b.pos) >> 1
+			break
+		}
+	}
+	if n < 0 {
+		b.fail(fmt.Errorf("mbuff.Buffer.TakeU16Until: sentinel 0x%04X not found after pos %d: %w", sentinel, b.pos, ErrOutOfRange))
+		return nil
+	}
+
+	v := make([]uint16, n)
+	readPos := b.pos
+	for i := range v {
+		v[i] = b.order.Uint16(b.data[readPos:])
+		readPos += 2
+	}
+	b.pos = readPos + 2
+	return v
+}
+
+// TakeU32UntilZero reads uint32 values at the current position until a zero
+// value, then advances the position past the zero and returns the values
+// before it, which may be empty. The position is left unchanged if the
+// readable region ends before a zero.
+func (b *Buffer) TakeU32UntilZero() []uint32 {
+	if b.err != nil {
+		return nil
+	}
+	n := -1
+	for p := b.pos; p+4 <= len(b.data); p += 4 {
+		if b.order.Uint32(b.data[p:]) == 0 {
+			n = (p - b.pos) >> 2
+			break
+		}
+	}
+	if n < 0 {
+		b.fail(fmt.Errorf("mbuff.Buffer.TakeU32UntilZero: zero terminator not found after pos %d: %w", b.pos, ErrOutOfRange))
+		return nil
+	}
+
+	v := make([]uint32, n)
+	readPos := b.pos
+	for i := range v {
+		v[i] = b.HLSwap32(b.order.Uint32(b.data[readPos:]))
+		readPos += 4
+	}
+	b.pos = readPos + 4
+	return v
+}
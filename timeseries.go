@@ -0,0 +1,89 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// TimeSeriesEncoder writes timestamps compactly using the delta-of-delta
+// scheme from Facebook's Gorilla paper, at nanosecond resolution.
+//
+// The stream starts with the base timestamp as a uint64 of Unix nanoseconds
+// (buffer byte order). Each following timestamp is written as a zig-zag
+// varint of (t[i] - t[i-1]) - (t[i-1] - t[i-2]), where the delta before the
+// first sample is taken as 0. Regularly spaced samples thus cost one byte.
+type TimeSeriesEncoder struct {
+	b         *Builder
+	prev      int64
+	prevDelta int64
+	started   bool
+}
+
+// NewTimeSeriesEncoder creates an encoder writing to b.
+func NewTimeSeriesEncoder(b *Builder) *TimeSeriesEncoder {
+	return &TimeSeriesEncoder{b: b}
+}
+
+// InitBase writes t as the absolute base timestamp and resets the encoder.
+func (e *TimeSeriesEncoder) InitBase(t time.Time) {
+	ns := t.UnixNano()
+	e.b.PutU64(uint64(ns))
+	e.prev, e.prevDelta, e.started = ns, 0, true
+}
+
+// AddTime writes t as a delta-of-delta from the previous timestamps.
+// Panics if InitBase has not been called.
+func (e *TimeSeriesEncoder) AddTime(t time.Time) {
+	if !e.started {
+		panic("mbuff.TimeSeriesEncoder.AddTime: base not initialized")
+	}
+	ns := t.UnixNano()
+	delta := ns - e.prev
+	var tmp [binary.MaxVarintLen64]byte
+	e.b.PutArr8(binary.AppendVarint(tmp[:0], delta-e.prevDelta))
+	e.prev, e.prevDelta = ns, delta
+}
+
+// TimeSeriesDecoder reads timestamps written by a TimeSeriesEncoder.
+type TimeSeriesDecoder struct {
+	b         *Buffer
+	prev      int64
+	prevDelta int64
+}
+
+// NewTimeSeriesDecoder creates a decoder reading from b.
+func NewTimeSeriesDecoder(b *Buffer) *TimeSeriesDecoder {
+	return &TimeSeriesDecoder{b: b}
+}
+
+// ReadBase reads the absolute base timestamp and resets the decoder.
+func (d *TimeSeriesDecoder) ReadBase() time.Time {
+	ns := int64(d.b.TakeU64())
+	d.prev, d.prevDelta = ns, 0
+	return time.Unix(0, ns)
+}
+
+// ReadTime reads the next timestamp. The position is left unchanged if the
+// varint is malformed or truncated.
+func (d *TimeSeriesDecoder) ReadTime() time.Time {
+	b := d.b
+	if b.err != nil {
+		return time.Time{}
+	}
+	dod, n := binary.Varint(b.data[b.pos:])
+	if n <= 0 {
+		b.fail(fmt.Errorf("mbuff.TimeSeriesDecoder.ReadTime: malformed varint at pos %d", b.pos))
+		return time.Time{}
+	}
+	b.pos += n
+	delta := d.prevDelta + dod
+	d.prev += delta
+	d.prevDelta = delta
+	return time.Unix(0, d.prev)
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTimeSeries tests delta-of-delta timestamp round trips.
+func TestTimeSeries(t *testing.T) {
+	base := time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)
+	times := []time.Time{
+		base.Add(time.Second),
+		base.Add(2 * time.Second),
+		base.Add(3 * time.Second),
+		base.Add(3*time.Second + time.Nanosecond),
+		base.Add(-1000 * time.Hour), // large gap backwards
+		base.Add(1000 * time.Hour),
+	}
+
+	b := NewBuilder(0)
+	e := NewTimeSeriesEncoder(b)
+	e.InitBase(base)
+	for _, tm := range times {
+		e.AddTime(tm)
+	}
+
+	// Regular samples cost a single byte after the first delta
+	assert.Equal(t, uint8(0), b.PeekAbsU8(8+5))
+
+	b.Rewind()
+	d := NewTimeSeriesDecoder(&b.Buffer)
+	assert.True(t, base.Equal(d.ReadBase()))
+	for _, tm := range times {
+		got := d.ReadTime()
+		assert.True(t, tm.Equal(got), "want %v, got %v", tm, got)
+	}
+	assert.Equal(t, 0, b.Readable())
+	assert.Panics(t, func() { d.ReadTime() })
+
+	assert.Panics(t, func() { NewTimeSeriesEncoder(b).AddTime(base) })
+}
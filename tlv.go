@@ -0,0 +1,129 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"fmt"
+)
+
+// TLV is a type-length-value record.
+type TLV struct {
+	Type  uint32 // record type
+	Value []byte // record value; its length is encoded implicitly
+}
+
+// maxUintN returns the largest value a width-byte field can hold,
+// or 0 if width is not 1, 2 or 4.
+func maxUintN(width int) uint64 {
+	switch width {
+	case 1:
+		return 0xFF
+	case 2:
+		return 0xFFFF
+	case 4:
+		return 0xFFFFFFFF
+	}
+	return 0
+}
+
+// putUintN writes v as a width-byte field at pos using the buffer's byte order.
+// The caller must have validated width and the available space.
+func (b *Buffer) putUintN(pos int, width int, v uint32) {
+	switch width {
+	case 1:
+		b.data[pos] = uint8(v)
+	case 2:
+		b.order.PutUint16(b.data[pos:], uint16(v))
+	default:
+		b.order.PutUint32(b.data[pos:], b.HLSwap32(v))
+	}
+}
+
+// uintN reads a width-byte field at pos using the buffer's byte order.
+// The caller must have validated width and the available data.
+func (b *Buffer) uintN(pos int, width int) uint32 {
+	switch width {
+	case 1:
+		return uint32(b.data[pos])
+	case 2:
+		return uint32(b.order.Uint16(b.data[pos:]))
+	default:
+		return b.HLSwap32(b.order.Uint32(b.data[pos:]))
+	}
+}
+
+// tlvListLen validates records for a width-byte type and length field and
+// returns their total encoded size.
+func tlvListLen(method string, width int, records []TLV) (int, error) {
+	max := maxUintN(width)
+	if max == 0 {
+		return 0, fmt.Errorf("mbuff.%s: invalid field width %d", method, width)
+	}
+	total := 0
+	for i, r := range records {
+		if uint64(r.Type) > max {
+			return 0, fmt.Errorf("mbuff.%s: record %d type %d exceeds %d-byte field", method, i, r.Type, width)
+		}
+		if uint64(len(r.Value)) > max {
+			return 0, fmt.Errorf("mbuff.%s: record %d length %d exceeds %d-byte field", method, i, len(r.Value), width)
+		}
+		total += width<<1 + len(r.Value)
+	}
+	return total, nil
+}
+
+// PutTLVList writes records at the current position as [type][length][value],
+// where type and length are width-byte fields (1, 2 or 4) in the buffer's
+// byte order, then advances the position.
+// Returns an error, without writing anything, if width is invalid or a type or
+// value length does not fit the field.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutTLVList(width int, records []TLV) error {
+	total, err := tlvListLen("Buffer.PutTLVList", width, records)
+	if err != nil {
+		return err
+	}
+	if !b.checkWritable("PutTLVList", b.pos+total) {
+		return nil
+	}
+
+	writePos := b.pos
+	for _, r := range records {
+		b.putUintN(writePos, width, r.Type)
+		b.putUintN(writePos+width, width, uint32(len(r.Value)))
+		writePos += width << 1
+		writePos += copy(b.data[writePos:], r.Value)
+	}
+	b.pos += total
+	return nil
+}
+
+// TakeTLVList reads records written by PutTLVList until the readable region
+// is exhausted, then advances the position. Values alias the buffer's storage.
+// Returns an error, without advancing, if width is invalid or the last record
+// is truncated.
+func (b *Buffer) TakeTLVList(width int) ([]TLV, error) {
+	if maxUintN(width) == 0 {
+		return nil, fmt.Errorf("mbuff.Buffer.TakeTLVList: invalid field width %d", width)
+	}
+	var records []TLV
+	readPos := b.pos
+	for readPos < len(b.data) {
+		if readPos+width<<1 > len(b.data) {
+			return nil, fmt.Errorf("mbuff.Buffer.TakeTLVList: truncated header at pos %d", readPos)
+		}
+		typ := b.uintN(readPos, width)
+		n := int(b.uintN(readPos+width, width))
+		readPos += width << 1
+		if readPos+n > len(b.data) {
+			return nil, fmt.Errorf("mbuff.Buffer.TakeTLVList: value of %d bytes at pos %d exceeds count %d", n, readPos, len(b.data))
+		}
+		records = append(records, TLV{Type: typ, Value: b.data[readPos : readPos+n : readPos+n]})
+		readPos += n
+	}
+	b.pos = readPos
+	return records, nil
+}
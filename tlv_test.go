@@ -0,0 +1,68 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTLVList tests writing and reading a list of TLV records.
+func TestTLVList(t *testing.T) {
+	records := []TLV{
+		{Type: 1, Value: []byte{0xAA}},
+		{Type: 2, Value: []byte{}},
+		{Type: 3, Value: []byte{0xBB, 0xCC}},
+	}
+
+	b := NewBuffer(16)
+	assert.NoError(t, b.PutTLVList(1, records))
+	assert.Equal(t, []byte{1, 1, 0xAA, 2, 0, 3, 2, 0xBB, 0xCC}, b.Bytes())
+
+	b.Rewind()
+	got, err := b.TakeTLVList(1)
+	assert.NoError(t, err)
+	assert.Equal(t, records, got)
+	assert.Equal(t, 9, b.Pos())
+
+	// Wider fields follow the buffer's byte order
+	b.Clear()
+	b.SetEndian(LittleEndian)
+	assert.NoError(t, b.PutTLVList(2, records[:1]))
+	assert.Equal(t, []byte{1, 0, 1, 0, 0xAA}, b.Bytes())
+
+	// Truncated record
+	b = NewBufferFrom([]byte{1, 3, 0xAA})
+	_, err = b.TakeTLVList(1)
+	assert.Error(t, err)
+	assert.Equal(t, 0, b.Pos())
+	_, err = b.TakeTLVList(3)
+	assert.Error(t, err)
+}
+
+// TestBuilder_PutTLVList tests validation and growth when writing TLV records.
+func TestBuilder_PutTLVList(t *testing.T) {
+	b := NewBuilder(0)
+	big := bytes.Repeat([]byte{0x55}, 300)
+
+	// Overflowing records fail before anything is written
+	assert.Error(t, b.PutTLVList(1, []TLV{{Type: 1, Value: []byte{1}}, {Type: 2, Value: big}}))
+	assert.Error(t, b.PutTLVList(1, []TLV{{Type: 256}}))
+	assert.Error(t, b.PutTLVList(8, nil))
+	assert.Equal(t, 0, b.Count())
+
+	assert.NoError(t, b.PutTLVList(4, []TLV{{Type: 7, Value: big}, {Type: 8, Value: []byte{1}}}))
+	assert.Equal(t, 8+300+8+1, b.Count())
+
+	b.Rewind()
+	got, err := b.TakeTLVList(4)
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, uint32(7), got[0].Type)
+	assert.Equal(t, big, got[0].Value)
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+// TraceOp describes one operation recorded while tracing is enabled.
+type TraceOp struct {
+	Method string // name of the method, e.g. "PutU16"
+	Offset int    // position at which the operation started
+	Width  int    // number of bytes written or read
+	Value  uint64 // value written or read; 0 for arrays and strings
+}
+
+// EnableTrace starts recording operations and discards any previous trace.
+// The scalar, array and string Put and Take methods (PutU8..PutU64,
+// PutArr8..PutArr64, PutStr and their Take counterparts) are recorded, as are
+// the explicit-order methods (PutU16In..PutU64In, which the LE and BE variants
+// call), varints, sized bytes, bit-packed arrays and ExpectU8..ExpectU64;
+// operations that fail are not. When tracing is disabled, the only cost is a
+// flag check per operation.
+func (b *Buffer) EnableTrace() {
+	b.tracing = true
+	b.trace = nil
+}
+
+// DisableTrace stops recording operations. The trace recorded so far is kept.
+func (b *Buffer) DisableTrace() { b.tracing = false }
+
+// Trace returns the operations recorded since EnableTrace, oldest first.
+func (b *Buffer) Trace() []TraceOp { return b.trace }
+
+// traceOp records an operation of width bytes at the current position.
+func (b *Buffer) traceOp(method string, width int, value uint64) {
+	if b.tracing {
+		b.trace = append(b.trace, TraceOp{Method: method, Offset: b.pos, Width: width, Value: value})
+	}
+}
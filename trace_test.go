@@ -0,0 +1,124 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTrace tests recording of Put and Take operations.
+func TestTrace(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutU8(1) // not traced
+	assert.Empty(t, b.Trace())
+
+	b.EnableTrace()
+	b.PutU16(0x0203)
+	b.PutU32(0x04050607)
+	b.PutStr("ab")
+	b.PutArr16([]uint16{1, 2})
+	b.DisableTrace()
+	b.PutU64(8) // not traced
+
+	expected := []TraceOp{
+		{Method: "PutU16", Offset: 1, Width: 2, Value: 0x0203},
+		{Method: "PutU32", Offset: 3, Width: 4, Value: 0x04050607},
+		{Method: "PutStr", Offset: 7, Width: 2},
+		{Method: "PutArr16", Offset: 9, Width: 4},
+	}
+	assert.Equal(t, expected, b.Trace())
+
+	// EnableTrace discards the previous trace
+	b.Rewind()
+	b.EnableTrace()
+	b.Skip(1)
+	assert.Equal(t, uint16(0x0203), b.TakeU16())
+	assert.Equal(t, uint32(0x04050607), b.TakeU32())
+	assert.Equal(t, "ab", b.TakeStr(2))
+	b.TakeArr16(make([]uint16, 2))
+	assert.Equal(t, uint64(8), b.TakeU64())
+	expected = []TraceOp{
+		{Method: "TakeU16", Offset: 1, Width: 2, Value: 0x0203},
+		{Method: "TakeU32", Offset: 3, Width: 4, Value: 0x04050607},
+		{Method: "TakeStr", Offset: 7, Width: 2},
+		{Method: "TakeArr16", Offset: 9, Width: 4},
+		{Method: "TakeU64", Offset: 13, Width: 8, Value: 8},
+	}
+	assert.Equal(t, expected, b.Trace())
+}
+
+// TestTrace_Failed tests that failed operations are not recorded.
+func TestTrace_Failed(t *testing.T) {
+	b := NewBuffer(2)
+	b.SetStrictMode(true)
+	b.EnableTrace()
+	b.PutU8(0xAA)
+	b.PutU32(1)
+	b.ClearErr()
+	b.Rewind()
+	b.TakeU16()
+	assert.Equal(t, []TraceOp{{Method: "PutU8", Offset: 0, Width: 1, Value: 0xAA}}, b.Trace())
+}
+
+// TestTrace_Extended tests recording of explicit-order, varint, sized, bit-packed
+// and Expect operations.
+func TestTrace_Extended(t *testing.T) {
+	b := NewBuilder(0)
+	b.EnableTrace()
+	b.PutU16LE(0x0102)
+	b.PutU32In(0x03040506, BigEndian)
+	b.PutU64BE(7)
+	b.PutUvarint(300)
+	b.PutVarint(-2)
+	b.PutSizedBytes([]byte("ab"))
+	b.PutBitsArr(12, []uint32{0xABC, 0xDEF})
+	expected := []TraceOp{
+		{Method: "PutU16In", Offset: 0, Width: 2, Value: 0x0102},
+		{Method: "PutU32In", Offset: 2, Width: 4, Value: 0x03040506},
+		{Method: "PutU64In", Offset: 6, Width: 8, Value: 7},
+		{Method: "PutUvarint", Offset: 14, Width: 2, Value: 300},
+		{Method: "PutVarint", Offset: 16, Width: 1, Value: 0xFFFFFFFFFFFFFFFE},
+		{Method: "PutSizedBytes", Offset: 17, Width: 3},
+		{Method: "PutBitsArr", Offset: 20, Width: 3},
+	}
+	assert.Equal(t, expected, b.Trace())
+
+	b.Rewind()
+	b.EnableTrace()
+	assert.Equal(t, uint16(0x0102), b.TakeU16LE())
+	assert.Equal(t, uint32(0x03040506), b.TakeU32In(BigEndian))
+	assert.Equal(t, uint64(7), b.TakeU64BE())
+	b.TakeUvarint()
+	b.TakeVarint()
+	b.TakeSizedBytes()
+	b.TakeBitsArr(12, make([]uint32, 2))
+	expected = []TraceOp{
+		{Method: "TakeU16In", Offset: 0, Width: 2, Value: 0x0102},
+		{Method: "TakeU32In", Offset: 2, Width: 4, Value: 0x03040506},
+		{Method: "TakeU64In", Offset: 6, Width: 8, Value: 7},
+		{Method: "TakeUvarint", Offset: 14, Width: 2, Value: 300},
+		{Method: "TakeVarint", Offset: 16, Width: 1, Value: 0xFFFFFFFFFFFFFFFE},
+		{Method: "TakeSizedBytes", Offset: 17, Width: 3},
+		{Method: "TakeBitsArr", Offset: 20, Width: 3},
+	}
+	assert.Equal(t, expected, b.Trace())
+
+	// Mismatched Expect calls are not recorded
+	b.Rewind()
+	b.EnableTrace()
+	assert.Error(t, b.ExpectU8(0xFF))
+	assert.NoError(t, b.ExpectU16(0x0201))
+	assert.NoError(t, b.ExpectU32(0x03040506))
+	assert.NoError(t, b.ExpectU64(7))
+	expected = []TraceOp{
+		{Method: "ExpectU16", Offset: 0, Width: 2, Value: 0x0201},
+		{Method: "ExpectU32", Offset: 2, Width: 4, Value: 0x03040506},
+		{Method: "ExpectU64", Offset: 6, Width: 8, Value: 7},
+	}
+	assert.Equal(t, expected, b.Trace())
+}
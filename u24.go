@@ -0,0 +1,83 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// MaxU24 is the largest value that fits in 24 bits.
+const MaxU24 = 0xFFFFFF
+
+// putU24 stores the low 24 bits of v at p in the buffer's byte order.
+func (b *Buffer) putU24(p int, v uint32) {
+	if b.order == binary.LittleEndian {
+		b.data[p], b.data[p+1], b.data[p+2] = byte(v), byte(v>>8), byte(v>>16)
+	} else {
+		b.data[p], b.data[p+1], b.data[p+2] = byte(v>>16), byte(v>>8), byte(v)
+	}
+}
+
+// u24 reads a 24-bit value at p in the buffer's byte order.
+func (b *Buffer) u24(p int) uint32 {
+	if b.order == binary.LittleEndian {
+		return uint32(b.data[p]) | uint32(b.data[p+1])<<8 | uint32(b.data[p+2])<<16
+	}
+	return uint32(b.data[p])<<16 | uint32(b.data[p+1])<<8 | uint32(b.data[p+2])
+}
+
+// checkU24 records a failure if v does not fit in 24 bits.
+func (b *Buffer) checkU24(method string, v uint32) bool {
+	if v > MaxU24 {
+		return b.fail(fmt.Errorf("mbuff.Buffer.%s: value 0x%X exceeds 0x%X", method, v, MaxU24))
+	}
+	return true
+}
+
+// PutU24 writes the 3-byte unsigned integer v at the current position and
+// advances the position. High-low swap does not apply.
+// Panics if v exceeds MaxU24 or the write would exceed the buffer's capacity,
+// unless error mode is enabled.
+func (b *Buffer) PutU24(v uint32) {
+	if !b.checkU24("PutU24", v) || !b.checkWritable("PutU24", b.pos+3) {
+		return
+	}
+
+	b.traceOp("PutU24", 3, uint64(v))
+	b.putU24(b.pos, v)
+	b.pos += 3
+}
+
+// TakeU24 reads a 3-byte unsigned integer at the current position, then
+// advances the position.
+func (b *Buffer) TakeU24() uint32 {
+	if !b.checkReadable(3) {
+		return 0
+	}
+	v := b.u24(b.pos)
+	b.traceOp("TakeU24", 3, uint64(v))
+	b.pos += 3
+	return v
+}
+
+// PeekU24 reads a 3-byte unsigned integer at pos+offset without advancing the position.
+func (b *Buffer) PeekU24(offset int) uint32 {
+	absPos, ok := b.checkPeekable(offset, 3)
+	if !ok {
+		return 0
+	}
+	return b.u24(absPos)
+}
+
+// OverwriteU24 overwrites a 3-byte unsigned integer at the specified offset.
+// Panics if v exceeds MaxU24, unless error mode is enabled.
+func (b *Buffer) OverwriteU24(offset int, v uint32) {
+	if !b.checkU24("OverwriteU24", v) || !b.checkOverwritable(offset, 3) {
+		return
+	}
+	b.putU24(offset, v)
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestU24 tests 3-byte integers in both byte orders.
+func TestU24(t *testing.T) {
+	b := NewBuilder(0)
+	b.PutU24(0x010203)
+	b.SetEndian(LittleEndian)
+	b.PutU24(0x040506)
+	b.PutU24(MaxU24)
+	assert.Equal(t, []byte{1, 2, 3, 6, 5, 4, 0xFF, 0xFF, 0xFF}, b.Bytes())
+
+	b.Rewind()
+	assert.Equal(t, uint32(0x030201), b.PeekU24(0))
+	assert.Equal(t, uint32(0x040506), b.PeekU24(3))
+	b.SetEndian(BigEndian)
+	assert.Equal(t, uint32(0x010203), b.TakeU24())
+	b.SetEndian(LittleEndian)
+	assert.Equal(t, uint32(0x040506), b.TakeU24())
+	assert.Equal(t, uint32(MaxU24), b.TakeU24())
+
+	b.OverwriteU24(1, 0xABCDEF)
+	assert.Equal(t, []byte{1, 0xEF, 0xCD, 0xAB, 5}, b.Bytes()[:5])
+
+	// Values beyond 24 bits are rejected without writing
+	assert.Panics(t, func() { b.PutU24(0x1000000) })
+	assert.Panics(t, func() { b.OverwriteU24(0, 0x1000000) })
+	assert.Equal(t, 9, b.Count())
+	assert.Panics(t, func() { b.TakeU24() })
+	assert.Panics(t, func() { b.PeekU24(7) })
+
+	fixed := NewBuffer(5)
+	fixed.SetStrictMode(true)
+	fixed.PutU24(1)
+	fixed.PutU24(2)
+	assert.ErrorIs(t, fixed.Err(), ErrOutOfRange)
+	assert.Equal(t, 3, fixed.Pos())
+}
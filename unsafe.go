@@ -0,0 +1,56 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"encoding/binary"
+	"unsafe"
+)
+
+// hostOrder is the byte order of the host.
+var hostOrder binary.ByteOrder = func() binary.ByteOrder {
+	x := uint16(1)
+	if *(*byte)(unsafe.Pointer(&x)) == 1 {
+		return binary.LittleEndian
+	}
+	return binary.BigEndian
+}()
+
+// isHostOrder reports whether 16-bit arrays can be bulk copied, i.e. the
+// buffer's byte order matches the host.
+func (b *Buffer) isHostOrder() bool { return b.order == hostOrder }
+
+// isHostLayout reports whether 32/64-bit arrays can be bulk copied, i.e. the
+// buffer's byte order matches the host and high-low swap is disabled.
+func (b *Buffer) isHostLayout() bool { return b.order == hostOrder && !b.hlswap }
+
+// bytesOf16 returns the memory of v as a byte slice.
+func bytesOf16(v []uint16) []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(v))), len(v)<<1)
+}
+
+// bytesOf32 returns the memory of v as a byte slice.
+func bytesOf32(v []uint32) []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(v))), len(v)<<2)
+}
+
+// bytesOf64 returns the memory of v as a byte slice.
+func bytesOf64(v []uint64) []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(v))), len(v)<<3)
+}
+
+// SharesStorage reports whether b and other may alias each other, i.e. their
+// backing arrays overlap anywhere up to capacity. Views created by Since share
+// storage with their parent; a buffer over a copy of the data does not. Empty buffers
+// with zero capacity never share storage.
+func (b *Buffer) SharesStorage(other *Buffer) bool {
+	if cap(b.data) == 0 || cap(other.data) == 0 {
+		return false
+	}
+	start1 := uintptr(unsafe.Pointer(unsafe.SliceData(b.data)))
+	start2 := uintptr(unsafe.Pointer(unsafe.SliceData(other.data)))
+	return start1 < start2+uintptr(cap(other.data)) && start2 < start1+uintptr(cap(b.data))
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"fmt"
+	"unicode/utf16"
+)
+
+// checkUTF16Len returns an error if n code units do not fit the uint16 prefix.
+func checkUTF16Len(method string, n int) error {
+	if n > 0xFFFF {
+		return fmt.Errorf("mbuff.%s: %d code units exceed uint16 prefix", method, n)
+	}
+	return nil
+}
+
+// putUTF16 writes the code unit count of units as a uint16, followed by the
+// code units. method names the Buffer method in errors.
+func (b *Buffer) putUTF16(method string, units []uint16) {
+	if err := checkUTF16Len("Buffer."+method, len(units)); err != nil {
+		b.fail(err)
+		return
+	}
+	byteLen := 2 + len(units)<<1
+	required := b.pos + byteLen
+	if !b.checkWritable(method, required) {
+		return
+	}
+
+	b.order.PutUint16(b.data[b.pos:], uint16(len(units)))
+	writePos := b.pos + 2
+	for _, u := range units {
+		b.order.PutUint16(b.data[writePos:], u)
+		writePos += 2
+	}
+	b.pos += byteLen
+}
+
+// PutUTF16String writes s encoded as UTF-16, preceded by its length in code
+// units as a uint16, then advances the position. The prefix and code units
+// follow the buffer's byte order; use LittleEndian for UTF-16LE as written by
+// Windows components. Characters outside the Basic Multilingual Plane take
+// two code units (a surrogate pair), and invalid UTF-8 is written as U+FFFD.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) PutUTF16String(s string) {
+	b.putUTF16("PutUTF16String", utf16.Encode([]rune(s)))
+}
+
+// TakeUTF16String reads a string written by PutUTF16String, then advances the
+// position. Unpaired surrogates are decoded as U+FFFD. The position is left
+// unchanged if the code units run past the readable region.
+func (b *Buffer) TakeUTF16String() string {
+	if !b.checkReadable(2) {
+		return ""
+	}
+	n := int(b.order.Uint16(b.data[b.pos:]))
+	byteLen := 2 + n<<1
+	if !b.checkReadable(byteLen) {
+		return ""
+	}
+
+	units := make([]uint16, n)
+	readPos := b.pos + 2
+	for i := range units {
+		units[i] = b.order.Uint16(b.data[readPos:])
+		readPos += 2
+	}
+	b.pos += byteLen
+	return string(utf16.Decode(units))
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestUTF16String tests UTF-16 string round trips and the wire format.
+func TestUTF16String(t *testing.T) {
+	b := NewBuilder(0)
+	b.SetEndian(LittleEndian)
+	b.PutUTF16String("Hé😀")
+	expected := []byte{
+		0x04, 0x00, // 4 code units
+		'H', 0x00,
+		0xE9, 0x00,
+		0x3D, 0xD8, 0x00, 0xDE, // surrogate pair for U+1F600
+	}
+	assert.Equal(t, expected, b.Bytes())
+
+	b.PutUTF16String("")
+	b.Rewind()
+	assert.Equal(t, "Hé😀", b.TakeUTF16String())
+	assert.Equal(t, "", b.TakeUTF16String())
+	assert.Equal(t, 0, b.Readable())
+
+	// Big-endian follows the buffer's byte order
+	be := NewBuffer(8)
+	be.PutUTF16String("A")
+	assert.Equal(t, []byte{0x00, 0x01, 0x00, 'A'}, be.Bytes())
+}
+
+// TestUTF16String_Invalid tests truncated input, unpaired surrogates and overflow.
+func TestUTF16String_Invalid(t *testing.T) {
+	// Odd number of bytes for the code units
+	b := NewBufferFrom([]byte{0x00, 0x02, 0x00, 'A', 0x00})
+	b.SetStrictMode(true)
+	assert.Equal(t, "", b.TakeUTF16String())
+	assert.ErrorIs(t, b.Err(), ErrOutOfRange)
+	assert.Equal(t, 0, b.Pos())
+
+	// Unpaired surrogate
+	b = NewBufferFrom([]byte{0x00, 0x01, 0xD8, 0x3D})
+	assert.Equal(t, "�", b.TakeUTF16String())
+
+	// Too many code units for the prefix
+	bb := NewBuilder(0)
+	assert.Panics(t, func() { bb.PutUTF16String(strings.Repeat("a", 0x10000)) })
+	assert.Equal(t, 0, bb.Capacity()) // rejected before growing
+	assert.Panics(t, func() { NewBuffer(3).PutUTF16String("a") })
+
+	// Errors name the failing method
+	bb.SetStrictMode(true)
+	bb.PutUTF16String(strings.Repeat("a", 0x10000))
+	assert.ErrorContains(t, bb.Err(), "mbuff.Builder.PutUTF16String: 65536 code units")
+	b = NewBuffer(3)
+	b.SetStrictMode(true)
+	b.PutUTF16String("a")
+	assert.ErrorContains(t, b.Err(), "mbuff.Buffer.PutUTF16String: buffer overflow")
+}
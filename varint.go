@@ -0,0 +1,135 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// UvarintLen returns the number of bytes needed to encode v as an unsigned
+// LEB128 varint, matching the length written by binary.PutUvarint.
+func UvarintLen(v uint64) int {
+	n := 1
+	for v >= 0x80 {
+		v >>= 7
+		n++
+	}
+	return n
+}
+
+// VarintLen returns the number of bytes needed to encode v as a zig-zag
+// LEB128 varint, matching the length written by binary.PutVarint.
+func VarintLen(v int64) int {
+	return UvarintLen(uint64(v<<1) ^ uint64(v>>63))
+}
+
+// PutUvarint writes v as an unsigned LEB128 varint, as binary.PutUvarint
+// does, and advances the position. It returns the number of bytes written.
+// Varints have their own byte order, so the buffer's endianness does not
+// apply. Panics if the write would exceed the buffer's capacity, unless error
+// mode is enabled, in which case nothing is written and 0 is returned.
+func (b *Buffer) PutUvarint(v uint64) int {
+	n := UvarintLen(v)
+	if !b.checkWritable("PutUvarint", b.pos+n) {
+		return 0
+	}
+
+	b.traceOp("PutUvarint", n, v)
+	binary.PutUvarint(b.data[b.pos:], v)
+	b.pos += n
+	return n
+}
+
+// PutVarint writes v as a zig-zag LEB128 varint, as binary.PutVarint does,
+// and advances the position. It returns the number of bytes written.
+// See PutUvarint.
+func (b *Buffer) PutVarint(v int64) int {
+	n := VarintLen(v)
+	if !b.checkWritable("PutVarint", b.pos+n) {
+		return 0
+	}
+
+	b.traceOp("PutVarint", n, uint64(v))
+	binary.PutVarint(b.data[b.pos:], v)
+	b.pos += n
+	return n
+}
+
+// decodeUvarint decodes an unsigned LEB128 varint at absolute position p and
+// returns it with its length in bytes, or 0, 0 after recording the failure if
+// the encoding is truncated or overflows 64 bits.
+func (b *Buffer) decodeUvarint(method string, p int) (uint64, int) {
+	v, n := binary.Uvarint(b.data[p:])
+	if n == 0 {
+		b.fail(fmt.Errorf("mbuff.Buffer.%s: truncated varint at pos %d: %w", method, p, ErrOutOfRange))
+		return 0, 0
+	}
+	if n < 0 {
+		b.fail(fmt.Errorf("mbuff.Buffer.%s: varint at pos %d overflows 64 bits", method, p))
+		return 0, 0
+	}
+	return v, n
+}
+
+// TakeUvarint reads an unsigned LEB128 varint, as written by
+// binary.PutUvarint, and returns it with the number of bytes consumed, then
+// advances the position. Varints have their own byte order, so the buffer's
+// endianness does not apply. The position is left unchanged, and 0, 0
+// returned, if the encoding is truncated or longer than
+// binary.MaxVarintLen64 bytes.
+func (b *Buffer) TakeUvarint() (uint64, int) {
+	if b.err != nil {
+		return 0, 0
+	}
+	v, n := b.decodeUvarint("TakeUvarint", b.pos)
+	if n > 0 {
+		b.traceOp("TakeUvarint", n, v)
+	}
+	b.pos += n
+	return v, n
+}
+
+// TakeVarint reads a zig-zag LEB128 varint, as written by binary.PutVarint,
+// and returns it with the number of bytes consumed, then advances the
+// position. See TakeUvarint.
+func (b *Buffer) TakeVarint() (int64, int) {
+	if b.err != nil {
+		return 0, 0
+	}
+	u, n := b.decodeUvarint("TakeVarint", b.pos)
+	if n > 0 {
+		b.traceOp("TakeVarint", n, uint64(unzigzag(u)))
+	}
+	b.pos += n
+	return unzigzag(u), n
+}
+
+// PeekUvarint reads an unsigned LEB128 varint at pos+offset and returns it
+// with its length in bytes, without advancing the position.
+func (b *Buffer) PeekUvarint(offset int) (uint64, int) {
+	absPos, ok := b.checkPeekable(offset, 0)
+	if !ok {
+		return 0, 0
+	}
+	return b.decodeUvarint("PeekUvarint", absPos)
+}
+
+// PeekVarint reads a zig-zag LEB128 varint at pos+offset and returns it with
+// its length in bytes, without advancing the position.
+func (b *Buffer) PeekVarint(offset int) (int64, int) {
+	absPos, ok := b.checkPeekable(offset, 0)
+	if !ok {
+		return 0, 0
+	}
+	u, n := b.decodeUvarint("PeekVarint", absPos)
+	return unzigzag(u), n
+}
+
+// unzigzag maps a zig-zag encoded value back to its signed value.
+func unzigzag(u uint64) int64 {
+	return int64(u>>1) ^ -int64(u&1)
+}
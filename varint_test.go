@@ -0,0 +1,115 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestVarintLen tests varint sizes against encoding/binary.
+func TestVarintLen(t *testing.T) {
+	var tmp [binary.MaxVarintLen64]byte
+	for _, v := range []uint64{0, 1, 0x7F, 0x80, 0x3FFF, 0x4000, 1<<56 - 1, 1 << 56, 1<<63 - 1, 1 << 63, math.MaxUint64} {
+		assert.Equal(t, binary.PutUvarint(tmp[:], v), UvarintLen(v), "v=%d", v)
+	}
+	for _, v := range []int64{0, 1, -1, 63, -64, 64, -65, 8191, -8192, 8192, math.MaxInt64, math.MinInt64} {
+		assert.Equal(t, binary.PutVarint(tmp[:], v), VarintLen(v), "v=%d", v)
+	}
+}
+
+// TestTakeVarint tests decoding varints with their encoded length.
+func TestTakeVarint(t *testing.T) {
+	b := NewBuilder(0)
+	b.SetEndian(LittleEndian) // varints ignore the byte order
+	var tmp [binary.MaxVarintLen64]byte
+	for _, v := range []uint64{0, 0x7F, 0x80, math.MaxUint64} {
+		b.PutArr8(tmp[:binary.PutUvarint(tmp[:], v)])
+	}
+	for _, v := range []int64{0, -1, 64, math.MinInt64} {
+		b.PutArr8(tmp[:binary.PutVarint(tmp[:], v)])
+	}
+	b.Rewind()
+
+	for _, want := range []struct {
+		v uint64
+		n int
+	}{{0, 1}, {0x7F, 1}, {0x80, 2}, {math.MaxUint64, 10}} {
+		v, n := b.PeekUvarint(0)
+		assert.Equal(t, want.v, v)
+		assert.Equal(t, want.n, n)
+		pos := b.Pos()
+		v, n = b.TakeUvarint()
+		assert.Equal(t, want.v, v)
+		assert.Equal(t, want.n, n)
+		assert.Equal(t, pos+n, b.Pos())
+	}
+	for _, want := range []struct {
+		v int64
+		n int
+	}{{0, 1}, {-1, 1}, {64, 2}, {math.MinInt64, 10}} {
+		v, n := b.PeekVarint(0)
+		assert.Equal(t, want.v, v)
+		assert.Equal(t, want.n, n)
+		v, n = b.TakeVarint()
+		assert.Equal(t, want.v, v)
+		assert.Equal(t, want.n, n)
+	}
+	assert.True(t, b.AtEnd())
+
+	// Truncated and overlong encodings leave the position unchanged
+	r := NewBufferFrom([]byte{0x80, 0x80})
+	assert.Panics(t, func() { r.TakeUvarint() })
+	r.SetStrictMode(true)
+	v, n := r.TakeUvarint()
+	assert.Equal(t, 0, int(v)+n)
+	assert.ErrorIs(t, r.Err(), ErrOutOfRange)
+	assert.Equal(t, 0, r.Pos())
+
+	r = NewBufferFrom(append(bytes.Repeat([]byte{0xFF}, 10), 0x01))
+	r.SetStrictMode(true)
+	_, n = r.TakeVarint()
+	assert.Equal(t, 0, n)
+	assert.ErrorContains(t, r.Err(), "overflows 64 bits")
+	assert.Equal(t, 0, r.Pos())
+}
+
+// TestPutVarint tests that written varints match encoding/binary and round-trip.
+func TestPutVarint(t *testing.T) {
+	var tmp [binary.MaxVarintLen64]byte
+	b := NewBuilder(0)
+	b.SetEndian(LittleEndian)
+	for _, v := range []uint64{0, 1, 0x7F, 0x80, 1<<63 - 1, math.MaxUint64} {
+		b.Clear()
+		n := b.PutUvarint(v)
+		assert.Equal(t, tmp[:binary.PutUvarint(tmp[:], v)], b.Bytes())
+		assert.Equal(t, n, b.Pos())
+		b.Rewind()
+		got, k := b.TakeUvarint()
+		assert.Equal(t, v, got)
+		assert.Equal(t, n, k)
+	}
+	for _, v := range []int64{0, -1, 63, -64, 64, math.MaxInt64, math.MinInt64} {
+		b.Clear()
+		n := b.PutVarint(v)
+		assert.Equal(t, tmp[:binary.PutVarint(tmp[:], v)], b.Bytes())
+		b.Rewind()
+		got, k := b.TakeVarint()
+		assert.Equal(t, v, got)
+		assert.Equal(t, n, k)
+	}
+
+	fixed := NewBuffer(2)
+	assert.Equal(t, 2, fixed.PutUvarint(0x80))
+	assert.Panics(t, func() { fixed.PutVarint(0) })
+	fixed.SetStrictMode(true)
+	assert.Equal(t, 0, fixed.PutVarint(0))
+	assert.ErrorIs(t, fixed.Err(), ErrOutOfRange)
+}
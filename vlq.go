@@ -0,0 +1,91 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"fmt"
+)
+
+// MaxVLQ is the largest value that fits in a 4-byte MIDI-style VLQ.
+const MaxVLQ = 0x0FFFFFFF
+
+// vlqLen returns the number of bytes needed to encode v as a VLQ.
+func vlqLen(v uint32) int {
+	n := 1
+	for v >>= 7; v != 0; v >>= 7 {
+		n++
+	}
+	return n
+}
+
+// PutVLQ writes v as a MIDI-style variable-length quantity and advances the
+// position. The value is split into 7-bit groups, most significant group
+// first, with the high bit set on every byte except the last. This is not
+// compatible with LEB128, which stores the least significant group first.
+// Panics if v exceeds MaxVLQ or the write would exceed the buffer's capacity,
+// unless error mode is enabled.
+func (b *Buffer) PutVLQ(v uint32) {
+	if v > MaxVLQ {
+		b.fail(fmt.Errorf("mbuff.Buffer.PutVLQ: value 0x%X exceeds 0x%X", v, MaxVLQ))
+		return
+	}
+	n := vlqLen(v)
+	if !b.checkWritable("PutVLQ", b.pos+n) {
+		return
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		c := uint8(v>>(7*i)) & 0x7F
+		if i != 0 {
+			c |= 0x80
+		}
+		b.data[b.pos] = c
+		b.pos++
+	}
+}
+
+// decodeVLQ decodes a VLQ at absolute position p and returns it with its
+// length in bytes, or 0, 0 after recording the failure if the encoding is
+// longer than 4 bytes or runs past the valid data.
+func (b *Buffer) decodeVLQ(method string, p int) (uint32, int) {
+	var v uint32
+	for i := 0; i < 4; i++ {
+		if p+i >= len(b.data) {
+			b.fail(fmt.Errorf("mbuff.Buffer.%s: truncated encoding at pos %d: %w", method, p, ErrOutOfRange))
+			return 0, 0
+		}
+		c := b.data[p+i]
+		v = v<<7 | uint32(c&0x7F)
+		if c&0x80 == 0 {
+			return v, i + 1
+		}
+	}
+	b.fail(fmt.Errorf("mbuff.Buffer.%s: encoding at pos %d exceeds 4 bytes", method, p))
+	return 0, 0
+}
+
+// TakeVLQ reads a MIDI-style variable-length quantity written by PutVLQ and
+// returns it with the number of bytes consumed, then advances the position.
+// The position is left unchanged, and 0, 0 returned, if the encoding is
+// longer than 4 bytes or runs past the readable region.
+func (b *Buffer) TakeVLQ() (uint32, int) {
+	if b.err != nil {
+		return 0, 0
+	}
+	v, n := b.decodeVLQ("TakeVLQ", b.pos)
+	b.pos += n
+	return v, n
+}
+
+// PeekVLQ reads a MIDI-style variable-length quantity at pos+offset and
+// returns it with its length in bytes, without advancing the position.
+func (b *Buffer) PeekVLQ(offset int) (uint32, int) {
+	absPos, ok := b.checkPeekable(offset, 0)
+	if !ok {
+		return 0, 0
+	}
+	return b.decodeVLQ("PeekVLQ", absPos)
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestVLQ tests MIDI-style variable-length quantities.
+func TestVLQ(t *testing.T) {
+	// Reference encodings from the Standard MIDI File specification
+	cases := []struct {
+		v   uint32
+		enc []byte
+	}{
+		{0x00000000, []byte{0x00}},
+		{0x00000040, []byte{0x40}},
+		{0x0000007F, []byte{0x7F}},
+		{0x00000080, []byte{0x81, 0x00}},
+		{0x00002000, []byte{0xC0, 0x00}},
+		{0x00003FFF, []byte{0xFF, 0x7F}},
+		{0x00004000, []byte{0x81, 0x80, 0x00}},
+		{0x00100000, []byte{0xC0, 0x80, 0x00}},
+		{0x001FFFFF, []byte{0xFF, 0xFF, 0x7F}},
+		{0x00200000, []byte{0x81, 0x80, 0x80, 0x00}},
+		{0x08000000, []byte{0xC0, 0x80, 0x80, 0x00}},
+		{MaxVLQ, []byte{0xFF, 0xFF, 0xFF, 0x7F}},
+	}
+
+	b := NewBuilder(0)
+	for _, c := range cases {
+		b.Clear()
+		b.PutVLQ(c.v)
+		assert.Equal(t, c.enc, b.Bytes())
+		b.Rewind()
+		v, n := b.PeekVLQ(0)
+		assert.Equal(t, c.v, v)
+		assert.Equal(t, len(c.enc), n)
+		assert.Equal(t, 0, b.Pos())
+		v, n = b.TakeVLQ()
+		assert.Equal(t, c.v, v)
+		assert.Equal(t, len(c.enc), n)
+		assert.Equal(t, len(c.enc), b.Pos())
+	}
+
+	assert.Panics(t, func() { b.PutVLQ(MaxVLQ + 1) })
+}
+
+// TestVLQ_Invalid tests rejecting over-long and truncated encodings.
+func TestVLQ_Invalid(t *testing.T) {
+	// Over-long encoding
+	b := NewBufferFrom([]byte{0x81, 0x80, 0x80, 0x80, 0x00})
+	assert.Panics(t, func() { b.TakeVLQ() })
+	assert.Equal(t, 0, b.Pos())
+
+	// Truncated encoding
+	b = NewBufferFrom([]byte{0x81, 0x80})
+	b.SetStrictMode(true)
+	v, n := b.TakeVLQ()
+	assert.Equal(t, uint32(0), v)
+	assert.Equal(t, 0, n)
+	assert.ErrorIs(t, b.Err(), ErrOutOfRange)
+	assert.Equal(t, 0, b.Pos())
+
+	// Overflow writes nothing
+	b = NewBuffer(1)
+	assert.Panics(t, func() { b.PutVLQ(0x80) })
+	assert.Equal(t, 0, b.Count())
+}
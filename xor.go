@@ -0,0 +1,30 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+// XORRange XORs the valid data in [start, end) in place with key, repeated
+// as needed and aligned so that data[start] is XORed with key[0]. The
+// position is not changed. Applying the same key twice restores the data.
+// An empty key is a no-op.
+// Panics if the range is out of bounds, unless error mode is enabled.
+func (b *Buffer) XORRange(start, end int, key []byte) {
+	if !b.checkRange("XORRange", start, end) {
+		return
+	}
+	if len(key) == 0 {
+		return
+	}
+	region := b.data[start:end]
+	for i := range region {
+		region[i] ^= key[i%len(key)]
+	}
+}
+
+// XORReadable XORs the readable region [pos:len] in place with key, as
+// XORRange does, e.g. to deobfuscate a payload before parsing it.
+func (b *Buffer) XORReadable(key []byte) {
+	b.XORRange(b.pos, len(b.data), key)
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestXORRange tests XORing a range with a repeating key.
+func TestXORRange(t *testing.T) {
+	b := NewBufferFrom([]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0xFF})
+	b.XORRange(1, 6, []byte{0x01, 0x02})
+	assert.Equal(t, []byte{0x00, 0x01, 0x02, 0x01, 0x02, 0xFE}, b.Bytes())
+	assert.Equal(t, 0, b.Pos())
+
+	// Applying the key again restores the data
+	b.XORRange(1, 6, []byte{0x01, 0x02})
+	assert.Equal(t, []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0xFF}, b.Bytes())
+
+	// Keys longer than the range and empty keys
+	b.XORRange(4, 6, []byte{0x10, 0x20, 0x30})
+	assert.Equal(t, []byte{0x00, 0x00, 0x00, 0x00, 0x10, 0xDF}, b.Bytes())
+	b.XORRange(0, 6, nil)
+	b.XORRange(3, 3, []byte{0xFF})
+	assert.Equal(t, []byte{0x00, 0x00, 0x00, 0x00, 0x10, 0xDF}, b.Bytes())
+
+	// Readable region
+	b.Seek(4)
+	b.XORReadable([]byte{0x10, 0xDF})
+	assert.Equal(t, []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, b.Bytes())
+
+	// Out of bounds
+	assert.Panics(t, func() { b.XORRange(-1, 2, []byte{1}) })
+	assert.Panics(t, func() { b.XORRange(3, 2, []byte{1}) })
+	assert.Panics(t, func() { b.XORRange(0, 7, []byte{1}) })
+	b.SetStrictMode(true)
+	b.XORRange(0, 7, []byte{1})
+	assert.ErrorIs(t, b.Err(), ErrOutOfRange)
+}
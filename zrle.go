@@ -0,0 +1,129 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"fmt"
+)
+
+// Zero-run compression tokens used by WriteCompressedZeros.
+const (
+	zrleEnd    = 0x00 // end of stream
+	zrleMaxLit = 0x7F // tokens 0x01..0x7F: that many literal bytes follow
+	zrleRun    = 0x80 // tokens 0x80..0xFF: a run of (token&0x7F)+2 zeros
+	zrleMinRun = 2
+	zrleMaxRun = 0x7F + zrleMinRun
+)
+
+// zrleEncode writes the zero-run compressed form of src, including the end
+// token, to dst and returns its length. dst may be nil to only compute the
+// length.
+func zrleEncode(dst, src []byte) int {
+	isRun := func(i int) bool { return src[i] == 0 && i+1 < len(src) && src[i+1] == 0 }
+	n := 0
+	for i := 0; i < len(src); {
+		if isRun(i) {
+			r := 2
+			for i+r < len(src) && src[i+r] == 0 && r < zrleMaxRun {
+				r++
+			}
+			if dst != nil {
+				dst[n] = zrleRun | byte(r-zrleMinRun)
+			}
+			n++
+			i += r
+			continue
+		}
+		j := i + 1
+		for j < len(src) && j-i < zrleMaxLit && !isRun(j) {
+			j++
+		}
+		if dst != nil {
+			dst[n] = byte(j - i)
+			copy(dst[n+1:], src[i:j])
+		}
+		n += 1 + j - i
+		i = j
+	}
+	if dst != nil {
+		dst[n] = zrleEnd
+	}
+	return n + 1
+}
+
+// WriteCompressedZeros writes p with runs of zeros compressed, then advances
+// the position. The output is a sequence of tokens, each a single byte:
+//
+//	0x00:        end of stream
+//	0x01..0x7F:  that many literal bytes follow
+//	0x80..0xFF:  a run of (token & 0x7F) + 2 zero bytes
+//
+// Single zeros stay in literals, and longer runs take several tokens, so
+// data without zero runs costs one byte per 127 plus the end token.
+// Panics if the write would exceed the buffer's capacity, unless error mode is enabled.
+func (b *Buffer) WriteCompressedZeros(p []byte) {
+	n := zrleEncode(nil, p)
+	required := b.pos + n
+	if !b.checkWritable("WriteCompressedZeros", required) {
+		return
+	}
+
+	zrleEncode(b.data[b.pos:required], p)
+	b.pos = required
+}
+
+// ReadCompressedZeros reads a stream written by WriteCompressedZeros up to and
+// including its end token, and writes the decompressed bytes to dst at its
+// position. The stream is validated before anything is written, so on error
+// neither b's position nor dst is changed.
+func (b *Buffer) ReadCompressedZeros(dst *Builder) error {
+	if b.err != nil {
+		return b.err
+	}
+	size := 0
+	p := b.pos
+	for {
+		if p >= len(b.data) {
+			return fmt.Errorf("mbuff.Buffer.ReadCompressedZeros: missing end token after pos %d: %w", b.pos, ErrOutOfRange)
+		}
+		t := b.data[p]
+		p++
+		if t == zrleEnd {
+			break
+		}
+		if t&zrleRun != 0 {
+			size += int(t&^zrleRun) + zrleMinRun
+			continue
+		}
+		if p+int(t) > len(b.data) {
+			return fmt.Errorf("mbuff.Buffer.ReadCompressedZeros: literal of %d bytes at pos %d exceeds count %d: %w", t, p-1, len(b.data), ErrOutOfRange)
+		}
+		size += int(t)
+		p += int(t)
+	}
+
+	out := dst.Claim(size)
+	if out == nil && size > 0 {
+		return dst.err
+	}
+	for p = b.pos; ; {
+		t := b.data[p]
+		p++
+		if t == zrleEnd {
+			break
+		}
+		if t&zrleRun != 0 {
+			r := int(t&^zrleRun) + zrleMinRun
+			clear(out[:r])
+			out = out[r:]
+			continue
+		}
+		out = out[copy(out, b.data[p:p+int(t)]):]
+		p += int(t)
+	}
+	b.pos = p
+	return nil
+}
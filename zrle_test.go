@@ -0,0 +1,59 @@
+// Copyright 2025 The Gromb Authors. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package mbuff
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCompressedZeros tests zero-run compression round trips and token layout.
+func TestCompressedZeros(t *testing.T) {
+	b := NewBuilder(0)
+	b.WriteCompressedZeros([]byte{1, 0, 2, 0, 0, 0, 3})
+	assert.Equal(t, []byte{0x03, 1, 0, 2, 0x81, 0x01, 3, 0x00}, b.Bytes())
+
+	// Runs longer than a token split, data without zeros costs one byte per 127
+	cases := [][]byte{
+		nil,
+		{0},
+		{0, 0},
+		make([]byte, 300),
+		seq(1, 300),
+		cat(seq(1, 5), make([]byte, 130), seq(1, 3), []byte{0}),
+	}
+	for _, in := range cases {
+		b.Clear()
+		b.WriteCompressedZeros(in)
+		if len(in) == 300 && in[1] != 0 {
+			assert.Equal(t, 300+3+1, b.Count())
+		}
+		b.PutU8(0xEE)
+		b.Rewind()
+		out := NewBuilder(0)
+		out.PutU8(0xAA)
+		assert.NoError(t, b.ReadCompressedZeros(out))
+		assert.Equal(t, cat([]byte{0xAA}, in), out.Bytes())
+		assert.Equal(t, uint8(0xEE), b.TakeU8())
+	}
+	b.Clear()
+	b.WriteCompressedZeros(make([]byte, 300))
+	assert.Equal(t, []byte{0xFF, 0xFF, 0xA8, 0x00}, b.Bytes())
+
+	// Invalid streams leave both sides untouched
+	for _, in := range [][]byte{{}, {0x81}, {0x03, 1, 2}} {
+		src := NewBufferFrom(in)
+		out := NewBuilder(0)
+		assert.ErrorIs(t, src.ReadCompressedZeros(out), ErrOutOfRange)
+		assert.Equal(t, 0, src.Pos())
+		assert.Equal(t, 0, out.Count())
+	}
+
+	fixed := NewBuffer(4)
+	assert.Panics(t, func() { fixed.WriteCompressedZeros(bytes.Repeat([]byte{1}, 4)) })
+}